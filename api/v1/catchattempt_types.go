@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CatchAttemptSpec defines the desired state of CatchAttempt
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type CatchAttemptSpec struct {
+	// Trainer is the name of the Trainer throwing the Ball
+	Trainer string `json:"trainer"`
+	// KubeMon is the name of the wild KubeMon to catch
+	KubeMon string `json:"kubemon"`
+	// Ball is the name of the Ball Item thrown, taken from the Inventory of the Trainer
+	Ball string `json:"ball"`
+}
+
+// CatchAttemptPhase is the state of a CatchAttempt
+// +kubebuilder:validation:Enum=Thrown;Caught;Escaped;Failed
+type CatchAttemptPhase string
+
+const (
+	// CatchAttemptThrown CatchAttempts have taken the Ball out of the Inventory, but are not resolved yet
+	CatchAttemptThrown  CatchAttemptPhase = "Thrown"
+	CatchAttemptCaught  CatchAttemptPhase = "Caught"
+	CatchAttemptEscaped CatchAttemptPhase = "Escaped"
+	// CatchAttemptFailed CatchAttempts could not throw a Ball at all
+	CatchAttemptFailed CatchAttemptPhase = "Failed"
+)
+
+// CatchAttemptStatus defines the observed state of CatchAttempt
+type CatchAttemptStatus struct {
+	Phase   CatchAttemptPhase `json:"phase,omitempty"`
+	Message string            `json:"message,omitempty"`
+	// Chance is the chance of the KubeMon being caught in percent
+	Chance int32 `json:"chance,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Trainer",type="string",JSONPath=".spec.trainer"
+//+kubebuilder:printcolumn:name="KubeMon",type="string",JSONPath=".spec.kubemon"
+//+kubebuilder:printcolumn:name="Ball",type="string",JSONPath=".spec.ball"
+//+kubebuilder:printcolumn:name="Chance",type="integer",JSONPath=".status.chance",priority=1
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+
+// CatchAttempt is the Schema for the catchattempts API
+type CatchAttempt struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CatchAttemptSpec   `json:"spec,omitempty"`
+	Status CatchAttemptStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CatchAttemptList contains a list of CatchAttempt
+type CatchAttemptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CatchAttempt `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CatchAttempt{}, &CatchAttemptList{})
+}
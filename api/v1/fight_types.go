@@ -17,37 +17,212 @@ limitations under the License.
 package v1
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// FightMode decides how the KubeMons of a Fight choose their actions
+// +kubebuilder:validation:Enum=Auto;Interactive
+type FightMode string
+
+const (
+	// FightModeAuto lets the KubeMons attack on their own
+	FightModeAuto FightMode = "Auto"
+	// FightModeInteractive waits each turn for both trainers to submit a FightAction
+	FightModeInteractive FightMode = "Interactive"
+)
+
+// KubeMonReference refers to a KubeMon, which may live in another namespace than the Fight
+type KubeMonReference struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the KubeMon, defaults to the namespace of the Fight.
+	// KubeMons in other namespaces can only fight if a FightGrant in their namespace allows it.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// FightReference refers to a Fight, which may live in another namespace than the referring object
+type FightReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func (r FightReference) String() string {
+	return r.Namespace + "/" + r.Name
+}
+
 // FightSpec defines the desired state of Fight
 type FightSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
 	//+kubebuilder:validation:Required
-	KubeMon1 string `json:"kubemon1"`
+	KubeMon1 KubeMonReference `json:"kubemon1"`
 	//+kubebuilder:validation:Required
-	KubeMon2 string `json:"kubemon2"`
+	KubeMon2 KubeMonReference `json:"kubemon2"`
+
+	//+kubebuilder:default=Auto
+	Mode FightMode `json:"mode,omitempty"`
+	// TurnTimeoutSeconds is how long an interactive Fight waits for the FightActions of a turn,
+	// before the missing ones are replaced by the default action
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=60
+	TurnTimeoutSeconds *int32 `json:"turnTimeoutSeconds,omitempty"`
+	// Seed seeds the random number generator deciding whether Moves hit, land critical hits and how much damage they deal.
+	// If unset, a random seed is chosen when the Fight starts. Fights between KubeMons with the same stats and the same seed play out identically.
+	Seed *int64 `json:"seed,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of a Fight after it finished or was aborted.
+	// Once the TTL expired, the Fight is deleted. If unset, the default of the manager is used.
+	//+kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// FightPhase is the state of a Fight
+// +kubebuilder:validation:Enum=Pending;InProgress;Finished;Aborted
+type FightPhase string
+
+const (
+	// FightPending Fights wait for their KubeMons to be ready
+	FightPending FightPhase = "Pending"
+	// FightInProgress Fights have started and neither KubeMon has fainted yet
+	FightInProgress FightPhase = "InProgress"
+	// FightFinished Fights ended with one KubeMon fainting or forfeiting, or in a draw with both KubeMons fainting in the same turn
+	FightFinished FightPhase = "Finished"
+	// FightAborted Fights ended without a winner, e.g. because both KubeMons forfeited, or one disappeared
+	FightAborted FightPhase = "Aborted"
+)
+
+const (
+	// FightConditionParticipantsReady reports whether both KubeMons exist and may fight
+	FightConditionParticipantsReady = "ParticipantsReady"
+	// FightConditionRewarded reports whether the winner of a finished Fight has received its experience and payout
+	FightConditionRewarded = "Rewarded"
+)
+
+// FightLogMaxEntries is the number of entries kept in the log in the status of a Fight.
+// Older entries are rolled into the log ConfigMap of the Fight.
+const FightLogMaxEntries = 20
+
+// FightLogEntry is a single event of a Fight, like a KubeMon using a Move
+type FightLogEntry struct {
+	// Turn is the turn the event happened in
+	Turn int32 `json:"turn"`
+	// Actor is the KubeMon, or for Items the Trainer, causing the event
+	Actor string `json:"actor,omitempty"`
+	// Move is the Move used by the actor
+	Move string `json:"move,omitempty"`
+	// Item is the Item used by the actor
+	Item string `json:"item,omitempty"`
+	// Target is the KubeMon affected by the event
+	Target string `json:"target,omitempty"`
+	// Damage is the damage dealt to the target
+	Damage int32 `json:"damage,omitempty"`
+	// Healed is the HP the actor restored
+	Healed int32 `json:"healed,omitempty"`
+	// TargetHP is the HP the target has left after the event
+	TargetHP *int32 `json:"targetHP,omitempty"`
+	// Critical is set if the Move landed a critical hit
+	Critical bool `json:"critical,omitempty"`
+	// Missed is set if the Move missed the target
+	Missed bool `json:"missed,omitempty"`
+	// Effectiveness is how well the Move worked against the type of the target: SuperEffective, NotVeryEffective or NoEffect.
+	// It is empty for neutral Moves.
+	Effectiveness string `json:"effectiveness,omitempty"`
+	// Ailment is the status ailment inflicted on the target, or the one affecting it
+	Ailment Ailment `json:"ailment,omitempty"`
+	// Message is a human readable description of the event
+	Message string `json:"message"`
+}
+
+// FightKubeMonState is the state of a KubeMon after a turn of a Fight
+type FightKubeMonState struct {
+	KubeMonReference `json:",inline"`
+	HP               int32           `json:"hp"`
+	Boosts           KubeMonBoosts   `json:"boosts,omitempty"`
+	Moves            []KubeMonMove   `json:"moves,omitempty"`
+	Ailment          *KubeMonAilment `json:"ailment,omitempty"`
+	// Items are the Items used on the KubeMon during the turn, which are taken from the Inventory of its owner once the turn is applied
+	Items []string `json:"items,omitempty"`
+}
+
+// FightTurnResult is the outcome of a turn of a Fight.
+// It is recorded in the status of the Fight before it is applied to the KubeMons, which skip turns they have already seen.
+type FightTurnResult struct {
+	Turn     int32               `json:"turn"`
+	KubeMons []FightKubeMonState `json:"kubemons,omitempty"`
+}
+
+// FightRewards records which rewards of a finished Fight have been handed out.
+// Each reward is marked before it is handed out, so it is never handed out twice.
+type FightRewards struct {
+	// Winner is set once the winner gained its experience and its win was counted
+	Winner bool `json:"winner,omitempty"`
+	// Loser is set once the loss of the loser was counted
+	Loser bool `json:"loser,omitempty"`
 }
 
 // FightStatus defines the observed state of Fight
 type FightStatus struct {
-	LastMessage string `json:"lastMessage"`
+	Phase       FightPhase `json:"phase,omitempty"`
+	LastMessage string     `json:"lastMessage"`
 	//+kubebuilder:validation:default:1
 	TurnNumber int32 `json:"turnNumber"`
-	//+kubebuilder:validation:Enum:1,2
-	//+kubebuilder:validation:default:1
-	NextMon int32 `json:"nextMon"`
+
+	// TurnStartedAt is the time the current turn of an interactive Fight started
+	TurnStartedAt *metav1.Time `json:"turnStartedAt,omitempty"`
+	// ActiveKubeMon1 is the KubeMon currently fighting for side 1, if it was switched out.
+	// It lives in the same namespace as KubeMon1.
+	ActiveKubeMon1 string `json:"activeKubemon1,omitempty"`
+	// ActiveKubeMon2 is the KubeMon currently fighting for side 2, if it was switched out.
+	// It lives in the same namespace as KubeMon2.
+	ActiveKubeMon2 string `json:"activeKubemon2,omitempty"`
+	// ClaimedKubeMons are all KubeMons the Fight has claimed, including the ones switched out again.
+	// They are recorded before they are claimed, so the Fight releases every one of them once it ends.
+	ClaimedKubeMons []KubeMonReference `json:"claimedKubemons,omitempty"`
+
+	// LastTurn is the outcome of the last turn, which is applied to the KubeMons before the next turn is resolved
+	LastTurn *FightTurnResult `json:"lastTurn,omitempty"`
+
+	// Winner is the KubeMon which won the Fight
+	Winner *KubeMonReference `json:"winner,omitempty"`
+	// Loser is the KubeMon which fainted or forfeited
+	Loser *KubeMonReference `json:"loser,omitempty"`
+	// Draw is set if both KubeMons fainted in the same turn, so the Fight finished without a winner or loser
+	Draw bool `json:"draw,omitempty"`
+	// Seed is the seed of the random number generator used by the Fight, taken from the spec or chosen when the Fight started
+	Seed *int64 `json:"seed,omitempty"`
+	// StartedAt is the time both KubeMons were ready and the Fight started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// FinishedAt is the time the Fight was finished or aborted
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+	// Rewards records the rewards of a finished Fight handed out so far
+	Rewards FightRewards `json:"rewards,omitempty"`
+
+	// Log holds the latest events of the Fight, oldest first
+	//+kubebuilder:validation:MaxItems=20
+	Log []FightLogEntry `json:"log,omitempty"`
+	// LogConfigMap is the name of the ConfigMap holding the entries rolled out of the log
+	LogConfigMap string `json:"logConfigMap,omitempty"`
+	// ArchivedLogEntries is the number of entries rolled into the log ConfigMap
+	ArchivedLogEntries int32 `json:"archivedLogEntries,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-//+kubebuilder:printcolumn:name="KubeMon 1",type="string",JSONPath=".spec.kubemon1"
-//+kubebuilder:printcolumn:name="KubeMon 2",type="string",JSONPath=".spec.kubemon2"
+//+kubebuilder:printcolumn:name="KubeMon 1",type="string",JSONPath=".spec.kubemon1.name"
+//+kubebuilder:printcolumn:name="KubeMon 2",type="string",JSONPath=".spec.kubemon2.name"
+//+kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Turn",type="integer",JSONPath=".status.turnNumber"
+//+kubebuilder:printcolumn:name="Winner",type="string",JSONPath=".status.winner.name"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Fight is the Schema for the fights API
 type Fight struct {
@@ -67,6 +242,46 @@ type FightList struct {
 	Items           []Fight `json:"items"`
 }
 
+// KubeMonKey returns the name of the KubeMon fighting for the given side at the start of the Fight
+func (f *Fight) KubeMonKey(side int32) types.NamespacedName {
+	ref := f.Spec.KubeMon1
+	if side == 2 {
+		ref = f.Spec.KubeMon2
+	}
+
+	name := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+	if name.Namespace == "" {
+		name.Namespace = f.Namespace
+	}
+	return name
+}
+
+// KubeMons returns the names of all KubeMons taking part in the Fight, including the ones switched in and out
+func (f *Fight) KubeMons() []types.NamespacedName {
+	mons := []types.NamespacedName{f.KubeMonKey(1), f.KubeMonKey(2)}
+	add := func(name types.NamespacedName) {
+		if name.Name != "" && !slices.Contains(mons, name) {
+			mons = append(mons, name)
+		}
+	}
+	add(types.NamespacedName{Namespace: mons[0].Namespace, Name: f.Status.ActiveKubeMon1})
+	add(types.NamespacedName{Namespace: mons[1].Namespace, Name: f.Status.ActiveKubeMon2})
+	for _, ref := range f.Status.ClaimedKubeMons {
+		add(types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name})
+	}
+	return mons
+}
+
+// IsActive reports whether the Fight has not ended yet
+func (f *Fight) IsActive() bool {
+	return f.Status.Phase != FightFinished && f.Status.Phase != FightAborted
+}
+
+// HasStarted reports whether the KubeMons of the Fight started fighting
+func (f *Fight) HasStarted() bool {
+	return f.Status.Phase != "" && f.Status.Phase != FightPending
+}
+
 func init() {
 	SchemeBuilder.Register(&Fight{}, &FightList{})
 }
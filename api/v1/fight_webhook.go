@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var fightlog = logf.Log.WithName("fight-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *Fight) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&fightValidator{client: mgr.GetClient()}).
+		Complete()
+}
+
+// FightKubeMonsField is the field index of the cache listing the Fights a KubeMon takes part in.
+// It has to be registered with IndexFightKubeMons before the webhook is set up.
+const FightKubeMonsField = ".kubemons"
+
+// IndexFightKubeMons returns the keys a Fight is indexed by in FightKubeMonsField, the names of all its KubeMons
+func IndexFightKubeMons(obj client.Object) []string {
+	mons := obj.(*Fight).KubeMons()
+	keys := make([]string, 0, len(mons))
+	for _, mon := range mons {
+		keys = append(keys, mon.String())
+	}
+	return keys
+}
+
+//+kubebuilder:webhook:path=/validate-kubemon-memetoasty-github-com-v1-fight,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubemon.memetoasty.github.com,resources=fights,verbs=create;update,versions=v1,name=vfight.kb.io,admissionReviewVersions=v1
+
+// fightValidator validates Fights, looking up their KubeMons and the other Fights they take part in from the cache.
+// The controller still keeps a KubeMon from fighting in two Fights at once, should the cache miss a Fight which just started.
+type fightValidator struct {
+	client client.Reader
+}
+
+var _ webhook.CustomValidator = &fightValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *fightValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	fight := obj.(*Fight)
+	fightlog.Info("validate create", "name", fight.Name)
+
+	allErrs, err := v.validateParticipants(ctx, fight)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return nil, fightInvalid(fight, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *fightValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldFight, fight := oldObj.(*Fight), newObj.(*Fight)
+	fightlog.Info("validate update", "name", fight.Name)
+
+	// Like for Jobs, the TTL may still be changed once the Fight started
+	oldSpec, spec := oldFight.Spec.DeepCopy(), fight.Spec.DeepCopy()
+	oldSpec.TTLSecondsAfterFinished, spec.TTLSecondsAfterFinished = nil, nil
+	if equality.Semantic.DeepEqual(oldSpec, spec) {
+		return nil, nil
+	}
+
+	if oldFight.HasStarted() {
+		return nil, fightInvalid(fight, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "the spec of a Fight cannot be changed once it started, except for ttlSecondsAfterFinished"),
+		})
+	}
+
+	allErrs, err := v.validateParticipants(ctx, fight)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return nil, fightInvalid(fight, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *fightValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateParticipants checks that the KubeMons of the Fight are able to fight each other.
+// KubeMons which do not exist yet are allowed, the Fight stays pending until they are created.
+func (v *fightValidator) validateParticipants(ctx context.Context, fight *Fight) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+	paths := []*field.Path{field.NewPath("spec", "kubemon1"), field.NewPath("spec", "kubemon2")}
+	mons := []types.NamespacedName{fight.KubeMonKey(1), fight.KubeMonKey(2)}
+
+	if mons[0] == mons[1] {
+		return append(allErrs, field.Invalid(paths[1], fight.Spec.KubeMon2, "a KubeMon cannot fight itself")), nil
+	}
+
+	for i, name := range mons {
+		var mon KubeMon
+		err := v.client.Get(ctx, name, &mon)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if mon.Status.HP != nil && *mon.Status.HP == 0 {
+			allErrs = append(allErrs, field.Forbidden(paths[i], fmt.Sprintf("KubeMon %s has fainted", name)))
+		}
+	}
+
+	for i, name := range mons {
+		var fights FightList
+		if err := v.client.List(ctx, &fights, client.MatchingFields{FightKubeMonsField: name.String()}); err != nil {
+			return nil, err
+		}
+		// Pending Fights queue up behind the Fight in progress, so only Fights in progress keep a KubeMon busy
+		if slices.ContainsFunc(fights.Items, func(other Fight) bool {
+			return (other.Namespace != fight.Namespace || other.Name != fight.Name) && other.Status.Phase == FightInProgress
+		}) {
+			allErrs = append(allErrs, field.Forbidden(paths[i], fmt.Sprintf("KubeMon %s is already fighting in another Fight", name)))
+		}
+	}
+	return allErrs, nil
+}
+
+func fightInvalid(fight *Fight, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Fight").GroupKind(), fight.Name, allErrs)
+}
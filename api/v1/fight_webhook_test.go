@@ -0,0 +1,96 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Fight Webhook", func() {
+
+	Context("When creating Fight under Validating Webhook", func() {
+		newFight := func(name, mon1, mon2 string) *Fight {
+			return &Fight{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: FightSpec{
+					KubeMon1: KubeMonReference{Name: mon1},
+					KubeMon2: KubeMonReference{Name: mon2},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			species := &Species{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-species"},
+				Spec:       SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+			}
+			err := k8sClient.Create(ctx, species)
+			if !apierrors.IsAlreadyExists(err) {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("Should deny a KubeMon fighting itself", func() {
+			fight := newFight("webhook-self-fight", "webhook-mon", "webhook-mon")
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, fight))).To(BeTrue())
+		})
+
+		It("Should deny a fainted KubeMon", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-fainted", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: "webhook-species", Strength: 1},
+			}
+			Expect(k8sClient.Create(ctx, mon)).To(Succeed())
+			mon.Status.HP = ptr.To[int32](0)
+			Expect(k8sClient.Status().Update(ctx, mon)).To(Succeed())
+
+			fight := newFight("webhook-fainted-fight", mon.Name, "webhook-mon")
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, fight))).To(BeTrue())
+		})
+
+		It("Should deny a KubeMon already fighting and changes after the start", func() {
+			fight := newFight("webhook-fight", "webhook-busy1", "webhook-busy2")
+			Expect(k8sClient.Create(ctx, fight)).To(Succeed())
+
+			By("Queueing a second Fight behind the pending one")
+			Expect(k8sClient.Create(ctx, newFight("webhook-queued-fight", "webhook-busy1", "webhook-mon"), client.DryRunAll)).To(Succeed())
+
+			By("Entering a KubeMon into a second Fight once the first started")
+			fight.Status.Phase = FightInProgress
+			Expect(k8sClient.Status().Update(ctx, fight)).To(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Create(ctx, newFight("webhook-second-fight", "webhook-busy1", "webhook-mon"), client.DryRunAll)
+				return apierrors.IsInvalid(err)
+			}).Should(BeTrue())
+
+			By("Changing the KubeMons once the Fight started")
+			fight.Spec.KubeMon2.Name = "webhook-mon"
+			Expect(apierrors.IsInvalid(k8sClient.Update(ctx, fight))).To(BeTrue())
+
+			By("Changing the TTL once the Fight started")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(fight), fight)).To(Succeed())
+			fight.Spec.TTLSecondsAfterFinished = ptr.To[int32](60)
+			Expect(k8sClient.Update(ctx, fight)).To(Succeed())
+		})
+	})
+
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FightActionType is the kind of action a trainer takes in a turn of an interactive Fight
+// +kubebuilder:validation:Enum=Move;Item;Switch;Forfeit
+type FightActionType string
+
+const (
+	// FightActionMove uses a Move of the active KubeMon
+	FightActionMove FightActionType = "Move"
+	// FightActionItem uses an Item from the owner's Inventory on the active KubeMon
+	FightActionItem FightActionType = "Item"
+	// FightActionSwitch replaces the active KubeMon with another KubeMon of the same owner
+	FightActionSwitch FightActionType = "Switch"
+	// FightActionForfeit gives up the Fight
+	FightActionForfeit FightActionType = "Forfeit"
+)
+
+// FightActionSpec defines the desired state of FightAction
+type FightActionSpec struct {
+	// Fight is the name of the Fight the action is taken in
+	//+kubebuilder:validation:Required
+	Fight string `json:"fight"`
+	// FightNamespace is the namespace of the Fight, defaults to the namespace of the FightAction.
+	// FightActions for a side have to be created in the namespace of the KubeMon fighting for that side.
+	FightNamespace string `json:"fightNamespace,omitempty"`
+	// Side is the side of the Fight the action is taken for, 1 for kubemon1 and 2 for kubemon2
+	//+kubebuilder:validation:Enum=1;2
+	Side int32 `json:"side"`
+	// Turn is the turn number of the Fight the action is meant for
+	//+kubebuilder:validation:Minimum=0
+	Turn int32 `json:"turn"`
+	//+kubebuilder:validation:Required
+	Type FightActionType `json:"type"`
+	// Move is the name of the Move to use, for actions of type Move
+	Move string `json:"move,omitempty"`
+	// Item is the name of the Item to use, for actions of type Item
+	Item string `json:"item,omitempty"`
+	// KubeMon is the name of the KubeMon to switch in, for actions of type Switch
+	KubeMon string `json:"kubemon,omitempty"`
+}
+
+// FightActionStatus defines the observed state of FightAction
+type FightActionStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Fight",type="string",JSONPath=".spec.fight"
+//+kubebuilder:printcolumn:name="Side",type="integer",JSONPath=".spec.side"
+//+kubebuilder:printcolumn:name="Turn",type="integer",JSONPath=".spec.turn"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+
+// FightAction is the Schema for the fightactions API
+type FightAction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FightActionSpec   `json:"spec,omitempty"`
+	Status FightActionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FightActionList contains a list of FightAction
+type FightActionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FightAction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FightAction{}, &FightActionList{})
+}
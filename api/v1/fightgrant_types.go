@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FightGrantFrom is a namespace allowed to create Fights
+type FightGrantFrom struct {
+	//+kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// FightGrantSpec defines the desired state of FightGrant
+type FightGrantSpec struct {
+	// From are the namespaces whose Fights may involve KubeMons of the namespace of the FightGrant
+	//+kubebuilder:validation:MinItems=1
+	From []FightGrantFrom `json:"from"`
+	// KubeMons are the names of the KubeMons which may be fought. If empty, all KubeMons of the namespace may be fought.
+	KubeMons []string `json:"kubemons,omitempty"`
+}
+
+// FightGrantStatus defines the observed state of FightGrant
+type FightGrantStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// FightGrant is the Schema for the fightgrants API.
+// Like a Gateway API ReferenceGrant, it is created in the namespace of the KubeMons and allows Fights
+// in other namespaces to involve them, without the namespaces granting each other any further access.
+type FightGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FightGrantSpec   `json:"spec,omitempty"`
+	Status FightGrantStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FightGrantList contains a list of FightGrant
+type FightGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FightGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FightGrant{}, &FightGrantList{})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HabitatLabel marks wild KubeMons with the name of the Habitat they spawned in
+	HabitatLabel = "kubemon.memetoasty.github.com/habitat"
+)
+
+// HabitatSpecies is a Species living in a Habitat
+// +kubebuilder:validation:XValidation:rule="!has(self.maxLevel) || self.minLevel <= self.maxLevel",message="minLevel must not be greater than maxLevel"
+type HabitatSpecies struct {
+	// Name is the name of the Species
+	Name string `json:"name"`
+	// Weight is the relative chance of the Species to spawn
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	Weight int32 `json:"weight,omitempty"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	//+kubebuilder:default=1
+	MinLevel int32 `json:"minLevel,omitempty"`
+	// MaxLevel defaults to MinLevel
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	MaxLevel int32 `json:"maxLevel,omitempty"`
+	// Moves are the names of the Moves spawned KubeMons know
+	//+kubebuilder:validation:MaxItems=4
+	Moves []string `json:"moves,omitempty"`
+}
+
+// HabitatSpec defines the desired state of Habitat
+type HabitatSpec struct {
+	//+kubebuilder:validation:MinItems=1
+	Species []HabitatSpecies `json:"species"`
+	// PopulationCap is the maximum number of wild KubeMons living in the Habitat at the same time
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=5
+	PopulationCap int32 `json:"populationCap,omitempty"`
+	// SpawnInterval is the time between two KubeMons spawning
+	//+kubebuilder:default="5m"
+	SpawnInterval *metav1.Duration `json:"spawnInterval,omitempty"`
+	// DespawnAfter is the time after which a wild KubeMon leaves the Habitat again, counted from when it spawned or last left a Fight.
+	// KubeMons never leave while they are fighting.
+	//+kubebuilder:default="1h"
+	DespawnAfter *metav1.Duration `json:"despawnAfter,omitempty"`
+}
+
+// HabitatStatus defines the observed state of Habitat
+type HabitatStatus struct {
+	// Population is the number of wild KubeMons living in the Habitat
+	Population int32 `json:"population"`
+	// LastSpawn is the time the last KubeMon spawned
+	LastSpawn *metav1.Time `json:"lastSpawn,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Population",type="integer",JSONPath=".status.population"
+//+kubebuilder:printcolumn:name="Cap",type="integer",JSONPath=".spec.populationCap"
+//+kubebuilder:printcolumn:name="Last Spawn",type="date",JSONPath=".status.lastSpawn"
+
+// Habitat is the Schema for the habitats API.
+// Habitats spawn wild KubeMons in their namespace.
+type Habitat struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HabitatSpec   `json:"spec,omitempty"`
+	Status HabitatStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// HabitatList contains a list of Habitat
+type HabitatList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Habitat `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Habitat{}, &HabitatList{})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ItemStack is a number of Items of the same kind
+type ItemStack struct {
+	// Name is the name of the Item
+	Name string `json:"name"`
+	//+kubebuilder:validation:Minimum=0
+	Quantity int32 `json:"quantity"`
+}
+
+// InventorySpec defines the desired state of Inventory
+type InventorySpec struct {
+}
+
+// InventoryStatus defines the observed state of Inventory
+type InventoryStatus struct {
+	// Items are the Items the owner has. They are only changed by the controller, e.g. when Items are bought or used.
+	//+listType=map
+	//+listMapKey=name
+	Items []ItemStack `json:"items,omitempty"`
+	// Receipts identify changes to the Items, which their source has not completed yet, e.g. the UIDs of Purchases being delivered.
+	// A change is only made once for each receipt.
+	//+listType=set
+	Receipts []string `json:"receipts,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Inventory is the Schema for the inventories API.
+// The Inventory of an owner is named after the owner and lives in the same namespace as its KubeMons.
+// Inventories are created and updated by the controller only.
+type Inventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InventorySpec   `json:"spec,omitempty"`
+	Status InventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// InventoryList contains a list of Inventory
+type InventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Inventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Inventory{}, &InventoryList{})
+}
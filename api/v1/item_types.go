@@ -0,0 +1,101 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ItemCategory decides what an Item does when it is used
+// +kubebuilder:validation:Enum=Potion;Revive;StatBooster;Ball;Cure;Evolution
+type ItemCategory string
+
+const (
+	// ItemCategoryPotion restores HP of a KubeMon which has not fainted
+	ItemCategoryPotion ItemCategory = "Potion"
+	// ItemCategoryRevive brings a fainted KubeMon back with half of its maximum HP
+	ItemCategoryRevive ItemCategory = "Revive"
+	// ItemCategoryStatBooster permanently raises a stat of a KubeMon
+	ItemCategoryStatBooster ItemCategory = "StatBooster"
+	// ItemCategoryBall is used to catch wild KubeMons
+	ItemCategoryBall ItemCategory = "Ball"
+	// ItemCategoryCure cures the status ailment of a KubeMon
+	ItemCategoryCure ItemCategory = "Cure"
+	// ItemCategoryEvolution makes a KubeMon evolve, if its Species has an evolution triggered by the Item
+	ItemCategoryEvolution ItemCategory = "Evolution"
+)
+
+// Stat is a stat of a KubeMon
+// +kubebuilder:validation:Enum=HP;Attack;Defense;Speed
+type Stat string
+
+const (
+	StatHP      Stat = "HP"
+	StatAttack  Stat = "Attack"
+	StatDefense Stat = "Defense"
+	StatSpeed   Stat = "Speed"
+)
+
+// ItemSpec defines the desired state of Item
+type ItemSpec struct {
+	//+kubebuilder:validation:Required
+	Category ItemCategory `json:"category"`
+	// HealAmount is the HP restored by a Potion
+	//+kubebuilder:validation:Minimum=0
+	HealAmount int32 `json:"healAmount,omitempty"`
+	// Stat is the stat raised by a StatBooster
+	Stat Stat `json:"stat,omitempty"`
+	// Boost is the amount a StatBooster raises its stat by
+	//+kubebuilder:validation:Minimum=0
+	Boost int32 `json:"boost,omitempty"`
+	// CatchBonus is the multiplier of a Ball on the catch rate in percent, 100 being a regular Ball
+	//+kubebuilder:validation:Minimum=0
+	CatchBonus int32 `json:"catchBonus,omitempty"`
+	// Cures are the status ailments cured by a Cure, all ailments if empty
+	Cures []Ailment `json:"cures,omitempty"`
+}
+
+// ItemStatus defines the observed state of Item
+type ItemStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Category",type="string",JSONPath=".spec.category"
+
+// Item is the Schema for the items API
+type Item struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ItemSpec   `json:"spec,omitempty"`
+	Status ItemStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ItemList contains a list of Item
+type ItemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Item `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Item{}, &ItemList{})
+}
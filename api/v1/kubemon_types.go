@@ -28,25 +28,121 @@ type KubeMonSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
+	// Species is the name of the cluster-scoped Species this KubeMon belongs to
 	Species string `json:"species"`
-	Owner   string `json:"owner,omitempty"`
+	// Owner is the name of the Trainer in the same namespace owning the KubeMon, empty for wild KubeMons
+	Owner string `json:"owner,omitempty"`
 	//+kubebuilder:validation:default:1
 	Strength int32 `json:"strength"`
+	// Moves are the names of the Moves the KubeMon knows
+	//+kubebuilder:validation:MaxItems=4
+	Moves []string `json:"moves,omitempty"`
+	// InitialLevel is the level the KubeMon starts at, defaults to 1
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	InitialLevel *int32 `json:"initialLevel,omitempty"`
+}
+
+// KubeMonBoosts are permanent raises of the stats of a KubeMon, e.g. by StatBooster Items
+type KubeMonBoosts struct {
+	HP      int32 `json:"hp,omitempty"`
+	Attack  int32 `json:"attack,omitempty"`
+	Defense int32 `json:"defense,omitempty"`
+	Speed   int32 `json:"speed,omitempty"`
+}
+
+// Ailment is a persistent status ailment of a KubeMon
+// +kubebuilder:validation:Enum=Poison;Burn;Sleep;Paralysis
+type Ailment string
+
+const (
+	// AilmentPoison hurts the KubeMon by an eighth of its maximum HP at the end of every turn of a Fight
+	AilmentPoison Ailment = "Poison"
+	// AilmentBurn hurts the KubeMon by a sixteenth of its maximum HP at the end of every turn of a Fight and halves the damage it deals
+	AilmentBurn Ailment = "Burn"
+	// AilmentSleep makes the KubeMon skip its turns until it wakes up
+	AilmentSleep Ailment = "Sleep"
+	// AilmentParalysis halves the speed of the KubeMon and makes it skip a quarter of its turns
+	AilmentParalysis Ailment = "Paralysis"
+)
+
+// KubeMonAilment is the status ailment a KubeMon suffers from
+type KubeMonAilment struct {
+	Type Ailment `json:"type"`
+	// SleepTurns is the number of turns a sleeping KubeMon keeps sleeping
+	SleepTurns int32 `json:"sleepTurns,omitempty"`
+}
+
+// KubeMonMove tracks the remaining PP of a Move known by a KubeMon
+type KubeMonMove struct {
+	Name string `json:"name"`
+	PP   int32  `json:"pp"`
 }
 
 // KubeMonStatus defines the observed state of KubeMon
 type KubeMonStatus struct {
 	HP *int32 `json:"hp,omitempty"`
-	//+kubebuilder:validation:Minimum:1
-	//+kubebuilder:validation:Minimum:99
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
 	Level *int32 `json:"level,omitempty"`
+	// XP is the total amount of experience the KubeMon has gathered
+	XP *int32 `json:"xp,omitempty"`
+
+	// Stats derived from the Species and the level of the KubeMon
+	MaxHP   *int32 `json:"maxHP,omitempty"`
+	Attack  *int32 `json:"attack,omitempty"`
+	Defense *int32 `json:"defense,omitempty"`
+	Speed   *int32 `json:"speed,omitempty"`
+	// Health is the HP and the maximum HP of the KubeMon as "HP/MaxHP", kept for printing
+	Health string `json:"health,omitempty"`
+	// Boosts are added on top of the stats derived from Species and level
+	Boosts KubeMonBoosts `json:"boosts,omitempty"`
+
+	Moves []KubeMonMove `json:"moves,omitempty"`
+
+	// Ailment is the status ailment the KubeMon suffers from. It lasts beyond Fights until it is cured.
+	Ailment *KubeMonAilment `json:"ailment,omitempty"`
+	// Friendship is how attached the KubeMon is to its Trainer, from 0 to 255
+	Friendship *int32 `json:"friendship,omitempty"`
+	// FriendshipHealedAt is the time the KubeMon last gained friendship by being healed
+	FriendshipHealedAt *metav1.Time `json:"friendshipHealedAt,omitempty"`
+	// EvolvedFrom is the Species the KubeMon had before it last evolved
+	EvolvedFrom string `json:"evolvedFrom,omitempty"`
+	// PreviousSpecies are all Species the KubeMon had before it evolved, oldest first. A KubeMon never evolves back into one of them.
+	//+kubebuilder:validation:MaxItems=8
+	PreviousSpecies []string `json:"previousSpecies,omitempty"`
+
+	Wins   int32 `json:"wins,omitempty"`
+	Losses int32 `json:"losses,omitempty"`
+
+	// CurrentFight is the Fight the KubeMon is currently taking part in.
+	// A KubeMon can only take part in one Fight at a time, other Fights wait until it is released.
+	CurrentFight *FightReference `json:"currentFight,omitempty"`
+	// FightTurn is the last turn of the current Fight applied to the KubeMon
+	FightTurn *int32 `json:"fightTurn,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// KubeMonConditionSpeciesResolved reports whether the Species referenced by the KubeMon exists
+	KubeMonConditionSpeciesResolved = "SpeciesResolved"
+	// KubeMonConditionMovesResolved reports whether all Moves known by the KubeMon exist
+	KubeMonConditionMovesResolved = "MovesResolved"
+	// KubeMonConditionTrainerResolved reports whether the Trainer owning the KubeMon exists
+	KubeMonConditionTrainerResolved = "TrainerResolved"
+	// KubeMonConditionInBattle reports whether the KubeMon is taking part in a Fight
+	KubeMonConditionInBattle = "InBattle"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Species",type="string",JSONPath=".spec.species"
 //+kubebuilder:printcolumn:name="Level",type="integer",JSONPath=".status.level"
-//+kubebuilder:printcolumn:name="HP",type="integer",JSONPath=".status.hp"
+//+kubebuilder:printcolumn:name="HP",type="string",JSONPath=".status.health"
+//+kubebuilder:printcolumn:name="Ailment",type="string",JSONPath=".status.ailment.type"
+//+kubebuilder:printcolumn:name="XP",type="integer",JSONPath=".status.xp",priority=1
+//+kubebuilder:printcolumn:name="Fight",type="string",JSONPath=".status.currentFight.name",priority=1
 
 // KubeMon is the Schema for the kubemons API
 type KubeMon struct {
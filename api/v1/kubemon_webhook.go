@@ -0,0 +1,194 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// KubeMonMinStrength is the lowest strength a KubeMon may have
+	KubeMonMinStrength = 1
+	// KubeMonMaxStrength is the highest strength a KubeMon may have
+	KubeMonMaxStrength = 255
+)
+
+// log is for logging in this package.
+var kubemonlog = logf.Log.WithName("kubemon-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *KubeMon) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	username, err := managerUsername(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("could not determine the user of the manager: %w", err)
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&kubeMonValidator{client: mgr.GetAPIReader(), manager: username}).
+		Complete()
+}
+
+// managerUsername asks the API server which user the manager authenticates as
+func managerUsername(cfg *rest.Config) (string, error) {
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return "", err
+	}
+	review := &authenticationv1.SelfSubjectReview{}
+	if err := c.Create(context.Background(), review); err != nil {
+		return "", err
+	}
+	return review.Status.UserInfo.Username, nil
+}
+
+//+kubebuilder:webhook:path=/validate-kubemon-memetoasty-github-com-v1-kubemon,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=create;update,versions=v1,name=vkubemon.kb.io,admissionReviewVersions=v1
+
+// kubeMonValidator validates KubeMons, looking up their Species without the cache, so freshly created Species are found
+type kubeMonValidator struct {
+	client client.Reader
+	// manager is the user the manager runs as. Only the manager may change the species of a KubeMon, once it checked the triggers of its evolution,
+	// and its owner, once it checked the catch.
+	manager string
+}
+
+var _ webhook.CustomValidator = &kubeMonValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *kubeMonValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mon := obj.(*KubeMon)
+	kubemonlog.Info("validate create", "name", mon.Name)
+
+	allErrs := validateKubeMonSpec(&mon.Spec, nil)
+
+	if mon.Spec.Species != "" {
+		speciesErrs, err := v.validateSpeciesExists(ctx, mon.Spec.Species)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, speciesErrs...)
+	}
+
+	return nil, kubeMonInvalid(mon, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *kubeMonValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldMon, mon := oldObj.(*KubeMon), newObj.(*KubeMon)
+	kubemonlog.Info("validate update", "name", mon.Name)
+
+	allErrs := validateKubeMonSpec(&mon.Spec, &oldMon.Spec)
+	speciesChanged := mon.Spec.Species != oldMon.Spec.Species && mon.Spec.Species != ""
+	if !speciesChanged && mon.Spec.Owner == oldMon.Spec.Owner {
+		return nil, kubeMonInvalid(mon, allErrs)
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if req.UserInfo.Username != v.manager {
+		if mon.Spec.Owner != oldMon.Spec.Owner {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "owner"), "the owner of a KubeMon cannot be changed, it only changes when the KubeMon is caught"))
+		}
+		if speciesChanged {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "species"), "the species of a KubeMon cannot be changed, it only changes when the KubeMon evolves"))
+		}
+		return nil, kubeMonInvalid(mon, allErrs)
+	}
+
+	if speciesChanged {
+		speciesErrs, err := v.validateEvolution(ctx, oldMon.Spec.Species, mon.Spec.Species)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, speciesErrs...)
+	}
+
+	return nil, kubeMonInvalid(mon, allErrs)
+}
+
+// validateSpeciesExists checks that the Species with the given name exists
+func (v *kubeMonValidator) validateSpeciesExists(ctx context.Context, name string) (field.ErrorList, error) {
+	var species Species
+	err := v.client.Get(ctx, client.ObjectKey{Name: name}, &species)
+	if apierrors.IsNotFound(err) {
+		return field.ErrorList{field.NotFound(field.NewPath("spec", "species"), name)}, nil
+	}
+	return nil, err
+}
+
+// validateEvolution checks that a KubeMon of the previous Species can evolve into the new one
+func (v *kubeMonValidator) validateEvolution(ctx context.Context, previous, name string) (field.ErrorList, error) {
+	speciesPath := field.NewPath("spec", "species")
+
+	var species Species
+	err := v.client.Get(ctx, client.ObjectKey{Name: previous}, &species)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(species.Spec.Evolutions, func(evolution Evolution) bool { return evolution.Species == name }) {
+		return field.ErrorList{field.Forbidden(speciesPath, fmt.Sprintf("the species of a KubeMon can only change by evolving, and %s does not evolve into %s", previous, name))}, nil
+	}
+	return v.validateSpeciesExists(ctx, name)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *kubeMonValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateKubeMonSpec checks the fields of a KubeMon which do not need to look up other objects.
+// On updates, only fields which changed from oldSpec are checked, so KubeMons stored before the webhook existed can still be updated.
+func validateKubeMonSpec(spec, oldSpec *KubeMonSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if spec.Species == "" && (oldSpec == nil || oldSpec.Species != "") {
+		allErrs = append(allErrs, field.Required(specPath.Child("species"), "a KubeMon needs a species"))
+	}
+	if (spec.Strength < KubeMonMinStrength || spec.Strength > KubeMonMaxStrength) && (oldSpec == nil || oldSpec.Strength != spec.Strength) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("strength"), spec.Strength,
+			fmt.Sprintf("must be between %d and %d", KubeMonMinStrength, KubeMonMaxStrength)))
+	}
+	if spec.Owner != "" && (oldSpec == nil || oldSpec.Owner != spec.Owner) {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.Owner) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("owner"), spec.Owner, msg))
+		}
+	}
+	return allErrs
+}
+
+func kubeMonInvalid(mon *KubeMon, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("KubeMon").GroupKind(), mon.Name, allErrs)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KubeMon Webhook", func() {
+
+	Context("When creating KubeMon under Validating Webhook", func() {
+		species := &Species{
+			ObjectMeta: metav1.ObjectMeta{Name: "webhook-species"},
+			Spec:       SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+		}
+
+		BeforeEach(func() {
+			err := k8sClient.Create(ctx, species.DeepCopy())
+			if !apierrors.IsAlreadyExists(err) {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("Should deny if the species does not exist", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-unknown-species", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: "unknown", Strength: 1},
+			}
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, mon))).To(BeTrue())
+		})
+
+		It("Should deny if the strength is out of bounds", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-negative-strength", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: species.Name, Strength: -1},
+			}
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, mon))).To(BeTrue())
+		})
+
+		It("Should deny if the owner is not a valid name", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-invalid-owner", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: species.Name, Strength: 1, Owner: "Not A Trainer"},
+			}
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, mon))).To(BeTrue())
+		})
+
+		It("Should admit if all fields are valid and deny changing the species", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-valid", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: species.Name, Strength: 1, Owner: "tobi"},
+			}
+			Expect(k8sClient.Create(ctx, mon)).To(Succeed())
+
+			By("Changing the species")
+			mon.Spec.Species = "unknown"
+			Expect(apierrors.IsInvalid(k8sClient.Update(ctx, mon))).To(BeTrue())
+
+			Expect(k8sClient.Delete(ctx, mon)).To(Succeed())
+		})
+
+		It("Should only check fields which changed on update", func() {
+			// KubeMons stored before the webhook existed may have an invalid strength
+			oldSpec := KubeMonSpec{Species: species.Name, Strength: 0}
+			spec := oldSpec
+			spec.Moves = []string{"tackle"}
+			Expect(validateKubeMonSpec(&spec, &oldSpec)).To(BeEmpty())
+			Expect(validateKubeMonSpec(&spec, nil)).NotTo(BeEmpty())
+
+			By("Changing the strength to another invalid value")
+			spec.Strength = -1
+			Expect(validateKubeMonSpec(&spec, &oldSpec)).NotTo(BeEmpty())
+		})
+
+		It("Should only admit the manager evolving KubeMons into a species they evolve into", func() {
+			evolved := &Species{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-evolved-species"},
+				Spec:       SpeciesSpec{BaseHP: 60, BaseAttack: 62, BaseDefense: 63, BaseSpeed: 60},
+			}
+			Expect(k8sClient.Create(ctx, evolved)).To(Succeed())
+			unevolved := &Species{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-unevolved-species"},
+				Spec: SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45,
+					Evolutions: []Evolution{{Species: evolved.Name, MinLevel: ptr.To(int32(16))}}},
+			}
+			Expect(k8sClient.Create(ctx, unevolved)).To(Succeed())
+
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-evolving", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: unevolved.Name, Strength: 1},
+			}
+			Expect(k8sClient.Create(ctx, mon)).To(Succeed())
+
+			By("Evolving as an ordinary user")
+			playerCfg := rest.CopyConfig(cfg)
+			playerCfg.Impersonate = rest.ImpersonationConfig{UserName: "player", Groups: []string{"system:masters"}}
+			playerClient, err := client.New(playerCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			mon.Spec.Species = evolved.Name
+			Expect(apierrors.IsInvalid(playerClient.Update(ctx, mon))).To(BeTrue())
+
+			By("Changing the species to one it does not evolve into as the manager")
+			mon.Spec.Species = species.Name
+			Expect(apierrors.IsInvalid(k8sClient.Update(ctx, mon))).To(BeTrue())
+
+			By("Evolving as the manager")
+			mon.Spec.Species = evolved.Name
+			Expect(k8sClient.Update(ctx, mon)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, mon)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, unevolved)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, evolved)).To(Succeed())
+		})
+
+		It("Should deny a Species evolving into itself", func() {
+			selfEvolving := &Species{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-self-evolving-species"},
+				Spec: SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45,
+					Evolutions: []Evolution{{Species: "webhook-self-evolving-species", MinLevel: ptr.To(int32(16))}}},
+			}
+			Expect(apierrors.IsInvalid(k8sClient.Create(ctx, selfEvolving))).To(BeTrue())
+		})
+
+		It("Should only admit the manager changing the owner", func() {
+			mon := &KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-owner", Namespace: "default"},
+				Spec:       KubeMonSpec{Species: species.Name, Strength: 1},
+			}
+			Expect(k8sClient.Create(ctx, mon)).To(Succeed())
+
+			By("Claiming a wild KubeMon as an ordinary user")
+			playerCfg := rest.CopyConfig(cfg)
+			playerCfg.Impersonate = rest.ImpersonationConfig{UserName: "player", Groups: []string{"system:masters"}}
+			playerClient, err := client.New(playerCfg, client.Options{Scheme: k8sClient.Scheme()})
+			Expect(err).NotTo(HaveOccurred())
+			mon.Spec.Owner = "player"
+			Expect(apierrors.IsInvalid(playerClient.Update(ctx, mon))).To(BeTrue())
+
+			By("Catching it as the manager")
+			mon.Spec.Owner = "tobi"
+			Expect(k8sClient.Update(ctx, mon)).To(Succeed())
+
+			By("Giving it to another Trainer as an ordinary user")
+			mon.Spec.Owner = "player"
+			Expect(apierrors.IsInvalid(playerClient.Update(ctx, mon))).To(BeTrue())
+
+			By("Updating other fields as an ordinary user")
+			mon.Spec.Owner = "tobi"
+			mon.Spec.Moves = []string{"tackle"}
+			Expect(playerClient.Update(ctx, mon)).To(Succeed())
+
+			Expect(k8sClient.Delete(ctx, mon)).To(Succeed())
+		})
+	})
+
+})
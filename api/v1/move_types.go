@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MoveEffect is an additional effect a Move has besides dealing damage
+// +kubebuilder:validation:Enum=Heal;Drain;Recoil
+type MoveEffect string
+
+const (
+	// MoveEffectHeal restores half of the user's maximum HP
+	MoveEffectHeal MoveEffect = "Heal"
+	// MoveEffectDrain restores half of the damage dealt to the user
+	MoveEffectDrain MoveEffect = "Drain"
+	// MoveEffectRecoil hurts the user by a quarter of the damage dealt
+	MoveEffectRecoil MoveEffect = "Recoil"
+)
+
+// MoveSpec defines the desired state of Move
+type MoveSpec struct {
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=255
+	Power int32 `json:"power"`
+	// Accuracy is the chance of the Move hitting its target in percent
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	//+kubebuilder:default=100
+	Accuracy int32  `json:"accuracy,omitempty"`
+	Type     string `json:"type,omitempty"`
+	// PP is the number of times the Move can be used before the KubeMon has to be healed
+	//+kubebuilder:validation:Minimum=1
+	PP int32 `json:"pp"`
+	// Moves with a higher priority are executed first
+	//+kubebuilder:validation:Minimum=-7
+	//+kubebuilder:validation:Maximum=7
+	Priority int32      `json:"priority,omitempty"`
+	Effect   MoveEffect `json:"effect,omitempty"`
+	// Ailment is the status ailment the Move may inflict on its target
+	Ailment Ailment `json:"ailment,omitempty"`
+	// AilmentChance is the chance of the Move inflicting its ailment in percent, defaults to 100
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	AilmentChance *int32 `json:"ailmentChance,omitempty"`
+}
+
+// MoveStatus defines the observed state of Move
+type MoveStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Power",type="integer",JSONPath=".spec.power"
+//+kubebuilder:printcolumn:name="Accuracy",type="integer",JSONPath=".spec.accuracy"
+//+kubebuilder:printcolumn:name="PP",type="integer",JSONPath=".spec.pp"
+
+// Move is the Schema for the moves API
+type Move struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MoveSpec   `json:"spec,omitempty"`
+	Status MoveStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MoveList contains a list of Move
+type MoveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Move `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Move{}, &MoveList{})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PurchaseSpec defines the desired state of Purchase
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+type PurchaseSpec struct {
+	// Shop is the name of the Shop in the same namespace
+	Shop string `json:"shop"`
+	// Buyer is the name of the Trainer paying for the Items
+	Buyer string `json:"buyer"`
+	// Item is the name of the Item to buy
+	Item string `json:"item"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	Quantity int32 `json:"quantity,omitempty"`
+}
+
+// PurchasePhase is the state of a Purchase
+// +kubebuilder:validation:Enum=Pending;Reserved;Paid;Completed;Failed
+type PurchasePhase string
+
+const (
+	// PurchasePending Purchases have not been processed yet
+	PurchasePending PurchasePhase = "Pending"
+	// PurchaseReserved Purchases have taken the Items out of the stock of the Shop and wait for their payment
+	PurchaseReserved PurchasePhase = "Reserved"
+	// PurchasePaid Purchases have been paid, but the Items have not been delivered yet
+	PurchasePaid      PurchasePhase = "Paid"
+	PurchaseCompleted PurchasePhase = "Completed"
+	PurchaseFailed    PurchasePhase = "Failed"
+)
+
+// PurchaseStatus defines the observed state of Purchase
+type PurchaseStatus struct {
+	Phase   PurchasePhase `json:"phase,omitempty"`
+	Message string        `json:"message,omitempty"`
+	// Price is the total price of the Purchase, fixed when the Items are reserved
+	Price int64 `json:"price,omitempty"`
+	// ReservedAt is the time the Items were taken out of the stock of the Shop
+	ReservedAt *metav1.Time `json:"reservedAt,omitempty"`
+	// Transaction is the name of the Transaction paying for the Purchase
+	Transaction string `json:"transaction,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Shop",type="string",JSONPath=".spec.shop"
+//+kubebuilder:printcolumn:name="Buyer",type="string",JSONPath=".spec.buyer"
+//+kubebuilder:printcolumn:name="Item",type="string",JSONPath=".spec.item"
+//+kubebuilder:printcolumn:name="Quantity",type="integer",JSONPath=".spec.quantity"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+
+// Purchase is the Schema for the purchases API
+type Purchase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PurchaseSpec   `json:"spec,omitempty"`
+	Status PurchaseStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PurchaseList contains a list of Purchase
+type PurchaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Purchase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Purchase{}, &PurchaseList{})
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ShopItem is an Item offered by a Shop
+type ShopItem struct {
+	// Name is the name of the Item
+	Name string `json:"name"`
+	// Price is the price of a single Item in coins
+	//+kubebuilder:validation:Minimum=1
+	Price int64 `json:"price"`
+	// Stock is the number of Items available after each restock
+	//+kubebuilder:validation:Minimum=0
+	Stock int32 `json:"stock"`
+}
+
+// ShopSpec defines the desired state of Shop
+type ShopSpec struct {
+	//+listType=map
+	//+listMapKey=name
+	Items []ShopItem `json:"items,omitempty"`
+	// RestockInterval is the time after which the stock of all Items is refilled
+	//+kubebuilder:default="1h"
+	RestockInterval *metav1.Duration `json:"restockInterval,omitempty"`
+}
+
+// ShopStatus defines the observed state of Shop
+type ShopStatus struct {
+	// Stock is the number of Items left until the next restock
+	//+listType=map
+	//+listMapKey=name
+	Stock []ItemStack `json:"stock,omitempty"`
+	// Sold is the number of Items sold since the last restock
+	//+listType=map
+	//+listMapKey=name
+	Sold []ItemStack `json:"sold,omitempty"`
+	// Reservations are the UIDs of the Purchases, which took Items out of the stock since the last restock
+	//+listType=set
+	Reservations []types.UID `json:"reservations,omitempty"`
+	// LastRestock is the time the stock was last refilled
+	LastRestock *metav1.Time `json:"lastRestock,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Restock Interval",type="string",JSONPath=".spec.restockInterval"
+//+kubebuilder:printcolumn:name="Last Restock",type="date",JSONPath=".status.lastRestock"
+
+// Shop is the Schema for the shops API
+type Shop struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ShopSpec   `json:"spec,omitempty"`
+	Status ShopStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ShopList contains a list of Shop
+type ShopList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Shop `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Shop{}, &ShopList{})
+}
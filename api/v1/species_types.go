@@ -0,0 +1,126 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthRate describes how much experience a Species needs to gain levels
+// +kubebuilder:validation:Enum=Fast;Medium;Slow;Erratic
+type GrowthRate string
+
+const (
+	GrowthRateFast    GrowthRate = "Fast"
+	GrowthRateMedium  GrowthRate = "Medium"
+	GrowthRateSlow    GrowthRate = "Slow"
+	GrowthRateErratic GrowthRate = "Erratic"
+)
+
+// TimeOfDay is a part of the day, in the time zone of the manager
+// +kubebuilder:validation:Enum=Day;Night
+type TimeOfDay string
+
+const (
+	// TimeOfDayDay lasts from 6:00 to 18:00
+	TimeOfDayDay TimeOfDay = "Day"
+	// TimeOfDayNight lasts from 18:00 to 6:00
+	TimeOfDayNight TimeOfDay = "Night"
+)
+
+// Evolution is a Species a KubeMon evolves into once all of the given triggers are met
+// +kubebuilder:validation:XValidation:rule="has(self.minLevel) || has(self.item) || has(self.minFriendship) || has(self.timeOfDay)",message="an evolution needs at least one trigger"
+type Evolution struct {
+	// Species is the name of the Species the KubeMon evolves into
+	//+kubebuilder:validation:MinLength=1
+	Species string `json:"species"`
+	// MinLevel is the level the KubeMon has to reach
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	MinLevel *int32 `json:"minLevel,omitempty"`
+	// Item is the name of an Item of the Evolution category, which has to be used on the KubeMon
+	Item string `json:"item,omitempty"`
+	// MinFriendship is the friendship the KubeMon has to have with its Trainer
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=255
+	MinFriendship *int32 `json:"minFriendship,omitempty"`
+	// TimeOfDay is the part of the day the KubeMon can evolve in
+	TimeOfDay TimeOfDay `json:"timeOfDay,omitempty"`
+}
+
+// SpeciesSpec defines the desired state of Species
+type SpeciesSpec struct {
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=255
+	BaseHP int32 `json:"baseHP"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=255
+	BaseAttack int32 `json:"baseAttack"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=255
+	BaseDefense int32 `json:"baseDefense"`
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=255
+	BaseSpeed int32  `json:"baseSpeed"`
+	Type      string `json:"type,omitempty"`
+	//+kubebuilder:default=Medium
+	GrowthRate GrowthRate `json:"growthRate,omitempty"`
+	// BaseExperience scales the experience a KubeMon gains for defeating a KubeMon of this Species
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=64
+	BaseExperience int32 `json:"baseExperience,omitempty"`
+	// CatchRate is how easily wild KubeMons of this Species are caught, from 1 (hardest) to 255 (easiest)
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=255
+	//+kubebuilder:default=45
+	CatchRate int32 `json:"catchRate,omitempty"`
+	// Evolutions are the Species KubeMons of this Species can evolve into. The first evolution whose triggers are met is used.
+	Evolutions []Evolution `json:"evolutions,omitempty"`
+}
+
+// SpeciesStatus defines the observed state of Species
+type SpeciesStatus struct {
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Growth Rate",type="string",JSONPath=".spec.growthRate"
+//+kubebuilder:validation:XValidation:rule="!has(self.spec.evolutions) || self.spec.evolutions.all(e, e.species != self.metadata.name)",message="a Species cannot evolve into itself"
+
+// Species is the Schema for the species API
+type Species struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SpeciesSpec   `json:"spec,omitempty"`
+	Status SpeciesStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SpeciesList contains a list of Species
+type SpeciesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Species `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Species{}, &SpeciesList{})
+}
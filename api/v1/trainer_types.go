@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrainerSpec defines the desired state of Trainer
+type TrainerSpec struct {
+	DisplayName string   `json:"displayName,omitempty"`
+	Badges      []string `json:"badges,omitempty"`
+	// Party are the names of the KubeMons the Trainer fights with
+	//+kubebuilder:validation:MaxItems=6
+	Party []string `json:"party,omitempty"`
+	// User is the name of the Kubernetes user allowed to spend the coins of the Trainer,
+	// e.g. "ash" or "system:serviceaccount:team-a:ash". Without a user, only the controller spends its coins.
+	User string `json:"user,omitempty"`
+}
+
+// TrainerStatus defines the observed state of Trainer
+type TrainerStatus struct {
+	// KubeMons is the number of KubeMons owned by the Trainer
+	KubeMons int32 `json:"kubemons"`
+	// Wins is the total number of Fights won by the KubeMons of the Trainer
+	Wins int32 `json:"wins"`
+	// Losses is the total number of Fights lost by the KubeMons of the Trainer
+	Losses int32 `json:"losses"`
+	// Balance is the amount of coins in the wallet of the Trainer, derived from its completed Transactions
+	Balance int64 `json:"balance"`
+	// Captures is the number of wild KubeMons the Trainer has caught
+	Captures int32 `json:"captures"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// TrainerConditionPartyValid reports whether all KubeMons in the party of the Trainer are owned by it
+	TrainerConditionPartyValid = "PartyValid"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Display Name",type="string",JSONPath=".spec.displayName"
+//+kubebuilder:printcolumn:name="KubeMons",type="integer",JSONPath=".status.kubemons"
+//+kubebuilder:printcolumn:name="Wins",type="integer",JSONPath=".status.wins"
+//+kubebuilder:printcolumn:name="Losses",type="integer",JSONPath=".status.losses"
+//+kubebuilder:printcolumn:name="Balance",type="integer",JSONPath=".status.balance"
+//+kubebuilder:printcolumn:name="Captures",type="integer",JSONPath=".status.captures",priority=1
+
+// Trainer is the Schema for the trainers API
+type Trainer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrainerSpec   `json:"spec,omitempty"`
+	Status TrainerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TrainerList contains a list of Trainer
+type TrainerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Trainer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Trainer{}, &TrainerList{})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// LedgerFinalizer keeps completed Transactions from being deleted, as the balances of Trainers are derived from them.
+	// The controller only removes it once the namespace of the Transaction is being deleted.
+	LedgerFinalizer = "kubemon.memetoasty.github.com/ledger"
+)
+
+// TransactionSpec defines the desired state of Transaction
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="spec is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.from) || has(self.to)",message="either from or to has to be set"
+type TransactionSpec struct {
+	// From is the name of the paying Trainer, empty for coins paid out by the game
+	From string `json:"from,omitempty"`
+	// To is the name of the receiving Trainer, empty for coins paid to the game
+	To string `json:"to,omitempty"`
+	//+kubebuilder:validation:Minimum=1
+	Amount int64 `json:"amount"`
+	// Fight is the name of the Fight a payout is made for
+	Fight string `json:"fight,omitempty"`
+	// FightNamespace is the namespace of the Fight, defaults to the namespace of the Transaction
+	FightNamespace string `json:"fightNamespace,omitempty"`
+	// FightUID is the UID of the Fight a payout is made for, so a Fight recreated under the same name is paid out again
+	FightUID types.UID `json:"fightUID,omitempty"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+// TransactionPhase is the state of a Transaction
+// +kubebuilder:validation:Enum=Pending;Completed;Failed
+type TransactionPhase string
+
+const (
+	TransactionPending   TransactionPhase = "Pending"
+	TransactionCompleted TransactionPhase = "Completed"
+	TransactionFailed    TransactionPhase = "Failed"
+)
+
+// TransactionStatus defines the observed state of Transaction
+type TransactionStatus struct {
+	Phase       TransactionPhase `json:"phase,omitempty"`
+	Message     string           `json:"message,omitempty"`
+	CompletedAt *metav1.Time     `json:"completedAt,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="From",type="string",JSONPath=".spec.from"
+//+kubebuilder:printcolumn:name="To",type="string",JSONPath=".spec.to"
+//+kubebuilder:printcolumn:name="Amount",type="integer",JSONPath=".spec.amount"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+
+// Transaction is the Schema for the transactions API.
+// Completed Transactions form the ledger the balances of Trainers are derived from.
+type Transaction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TransactionSpec   `json:"spec,omitempty"`
+	Status TransactionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TransactionList contains a list of Transaction
+type TransactionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Transaction `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Transaction{}, &TransactionList{})
+}
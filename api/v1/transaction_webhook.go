@@ -0,0 +1,140 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var transactionlog = logf.Log.WithName("transaction-resource")
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func (r *Transaction) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	username, err := managerUsername(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("could not determine the user of the manager: %w", err)
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&transactionValidator{manager: username, reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-kubemon-memetoasty-github-com-v1-transaction,mutating=false,failurePolicy=fail,sideEffects=None,groups=kubemon.memetoasty.github.com,resources=transactions,verbs=create;update;delete,versions=v1,name=vtransaction.kb.io,admissionReviewVersions=v1
+
+// transactionValidator makes sure only the user of a Trainer spends its coins, and that completed Transactions stay in the ledger.
+// Only the manager may spend coins on behalf of any Trainer, e.g. for Purchases.
+type transactionValidator struct {
+	// manager is the user the manager runs as
+	manager string
+	// reader reads Trainers and Namespaces directly from the API server
+	reader client.Reader
+}
+
+var _ webhook.CustomValidator = &transactionValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *transactionValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	transaction := obj.(*Transaction)
+	transactionlog.Info("validate create", "name", transaction.Name)
+
+	if transaction.Spec.From == "" {
+		return nil, nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if req.UserInfo.Username == v.manager {
+		return nil, nil
+	}
+
+	// Trainers are looked up in the namespace of the Transaction, so a user may only spend the coins of the Trainers mapped to them there
+	trainer := &Trainer{}
+	err = v.reader.Get(ctx, types.NamespacedName{Namespace: transaction.Namespace, Name: transaction.Spec.From}, trainer)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if err == nil && trainer.Spec.User != "" && trainer.Spec.User == req.UserInfo.Username {
+		return nil, nil
+	}
+
+	allErrs := field.ErrorList{field.Forbidden(field.NewPath("spec", "from"), fmt.Sprintf("only the user of Trainer %s may spend its coins", transaction.Spec.From))}
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("Transaction").GroupKind(), transaction.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *transactionValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTransaction := oldObj.(*Transaction)
+	transaction := newObj.(*Transaction)
+	transactionlog.Info("validate update", "name", transaction.Name)
+
+	if !controllerutil.ContainsFinalizer(oldTransaction, LedgerFinalizer) || controllerutil.ContainsFinalizer(transaction, LedgerFinalizer) {
+		return nil, nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if req.UserInfo.Username == v.manager {
+		return nil, nil
+	}
+
+	allErrs := field.ErrorList{field.Forbidden(field.NewPath("metadata", "finalizers"), fmt.Sprintf("only the controller may remove the %s finalizer", LedgerFinalizer))}
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("Transaction").GroupKind(), transaction.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *transactionValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	transaction := obj.(*Transaction)
+	transactionlog.Info("validate delete", "name", transaction.Name)
+
+	if transaction.Status.Phase != TransactionCompleted {
+		return nil, nil
+	}
+
+	// Completed Transactions only leave the ledger together with their namespace
+	namespace := &corev1.Namespace{}
+	if err := v.reader.Get(ctx, types.NamespacedName{Name: transaction.Namespace}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, apierrors.NewInternalError(err)
+	}
+	if namespace.DeletionTimestamp != nil {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewForbidden(GroupVersion.WithResource("transactions").GroupResource(), transaction.Name,
+		fmt.Errorf("completed Transactions are part of the ledger and are only deleted along with their namespace"))
+}
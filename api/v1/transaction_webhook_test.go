@@ -0,0 +1,143 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("Transaction Webhook", func() {
+	var ashClient client.Client
+
+	BeforeEach(func() {
+		ashCfg := rest.CopyConfig(cfg)
+		ashCfg.Impersonate = rest.ImpersonationConfig{UserName: "ash", Groups: []string{"system:masters"}}
+		var err error
+		ashClient, err = client.New(ashCfg, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		// ash owns the Trainer ash in default, while the Trainer ash in webhook-other belongs to gary
+		objects := []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "webhook-other"}},
+			&Trainer{ObjectMeta: metav1.ObjectMeta{Name: "ash", Namespace: "default"}, Spec: TrainerSpec{User: "ash"}},
+			&Trainer{ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"}, Spec: TrainerSpec{User: "tobi"}},
+			&Trainer{ObjectMeta: metav1.ObjectMeta{Name: "ash", Namespace: "webhook-other"}, Spec: TrainerSpec{User: "gary"}},
+		}
+		for _, object := range objects {
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, object))).To(Succeed())
+		}
+	})
+
+	Context("When creating Transaction under Validating Webhook", func() {
+		It("Should deny spending the coins of another Trainer", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-other-trainer", Namespace: "default"},
+				Spec:       TransactionSpec{From: "tobi", To: "ash", Amount: 50},
+			}
+			Expect(apierrors.IsInvalid(ashClient.Create(ctx, transaction))).To(BeTrue())
+		})
+
+		It("Should admit spending own coins", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-own-coins", Namespace: "default"},
+				Spec:       TransactionSpec{From: "ash", To: "tobi", Amount: 50},
+			}
+			Expect(ashClient.Create(ctx, transaction)).To(Succeed())
+		})
+
+		It("Should deny spending the coins of a Trainer with the same name in another namespace", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-other-namespace", Namespace: "webhook-other"},
+				Spec:       TransactionSpec{From: "ash", Amount: 50},
+			}
+			Expect(apierrors.IsInvalid(ashClient.Create(ctx, transaction))).To(BeTrue())
+		})
+
+		It("Should deny spending the coins of an unknown Trainer", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-unknown-trainer", Namespace: "default"},
+				Spec:       TransactionSpec{From: "gary", Amount: 50},
+			}
+			Expect(apierrors.IsInvalid(ashClient.Create(ctx, transaction))).To(BeTrue())
+		})
+
+		It("Should admit payouts", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-payout", Namespace: "default"},
+				Spec:       TransactionSpec{To: "ash", Amount: 50, Fight: "fight-sample"},
+			}
+			Expect(ashClient.Create(ctx, transaction)).To(Succeed())
+		})
+
+		It("Should admit the manager spending the coins of any Trainer", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-manager", Namespace: "default"},
+				Spec:       TransactionSpec{From: "tobi", Amount: 50},
+			}
+			Expect(k8sClient.Create(ctx, transaction)).To(Succeed())
+		})
+	})
+
+	Context("When updating Transaction under Validating Webhook", func() {
+		It("Should deny removing the ledger finalizer", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-finalizer", Namespace: "default", Finalizers: []string{LedgerFinalizer}},
+				Spec:       TransactionSpec{From: "ash", To: "tobi", Amount: 50},
+			}
+			Expect(k8sClient.Create(ctx, transaction)).To(Succeed())
+
+			controllerutil.RemoveFinalizer(transaction, LedgerFinalizer)
+			Expect(apierrors.IsInvalid(ashClient.Update(ctx, transaction))).To(BeTrue())
+
+			By("Letting the manager remove it")
+			Expect(k8sClient.Update(ctx, transaction)).To(Succeed())
+		})
+	})
+
+	Context("When deleting Transaction under Validating Webhook", func() {
+		It("Should deny deleting a completed Transaction", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-completed", Namespace: "default"},
+				Spec:       TransactionSpec{From: "ash", To: "tobi", Amount: 50},
+			}
+			Expect(k8sClient.Create(ctx, transaction)).To(Succeed())
+			transaction.Status.Phase = TransactionCompleted
+			Expect(k8sClient.Status().Update(ctx, transaction)).To(Succeed())
+
+			Expect(apierrors.IsForbidden(ashClient.Delete(ctx, transaction))).To(BeTrue())
+		})
+
+		It("Should admit deleting a failed Transaction", func() {
+			transaction := &Transaction{
+				ObjectMeta: metav1.ObjectMeta{Name: "webhook-failed", Namespace: "default"},
+				Spec:       TransactionSpec{From: "ash", To: "tobi", Amount: 50},
+			}
+			Expect(k8sClient.Create(ctx, transaction)).To(Succeed())
+			transaction.Status.Phase = TransactionFailed
+			Expect(k8sClient.Status().Update(ctx, transaction)).To(Succeed())
+
+			Expect(ashClient.Delete(ctx, transaction)).To(Succeed())
+		})
+	})
+
+})
@@ -21,11 +21,13 @@ limitations under the License.
 package v1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Fight) DeepCopyInto(out *Fight) {
+func (in *CatchAttempt) DeepCopyInto(out *CatchAttempt) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -33,6 +35,120 @@ func (in *Fight) DeepCopyInto(out *Fight) {
 	out.Status = in.Status
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatchAttempt.
+func (in *CatchAttempt) DeepCopy() *CatchAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(CatchAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CatchAttempt) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatchAttemptList) DeepCopyInto(out *CatchAttemptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CatchAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatchAttemptList.
+func (in *CatchAttemptList) DeepCopy() *CatchAttemptList {
+	if in == nil {
+		return nil
+	}
+	out := new(CatchAttemptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CatchAttemptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatchAttemptSpec) DeepCopyInto(out *CatchAttemptSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatchAttemptSpec.
+func (in *CatchAttemptSpec) DeepCopy() *CatchAttemptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CatchAttemptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CatchAttemptStatus) DeepCopyInto(out *CatchAttemptStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatchAttemptStatus.
+func (in *CatchAttemptStatus) DeepCopy() *CatchAttemptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CatchAttemptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Evolution) DeepCopyInto(out *Evolution) {
+	*out = *in
+	if in.MinLevel != nil {
+		in, out := &in.MinLevel, &out.MinLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinFriendship != nil {
+		in, out := &in.MinFriendship, &out.MinFriendship
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Evolution.
+func (in *Evolution) DeepCopy() *Evolution {
+	if in == nil {
+		return nil
+	}
+	out := new(Evolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fight) DeepCopyInto(out *Fight) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fight.
 func (in *Fight) DeepCopy() *Fight {
 	if in == nil {
@@ -52,31 +168,58 @@ func (in *Fight) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FightList) DeepCopyInto(out *FightList) {
+func (in *FightAction) DeepCopyInto(out *FightAction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightAction.
+func (in *FightAction) DeepCopy() *FightAction {
+	if in == nil {
+		return nil
+	}
+	out := new(FightAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FightAction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightActionList) DeepCopyInto(out *FightActionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Fight, len(*in))
+		*out = make([]FightAction, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightList.
-func (in *FightList) DeepCopy() *FightList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightActionList.
+func (in *FightActionList) DeepCopy() *FightActionList {
 	if in == nil {
 		return nil
 	}
-	out := new(FightList)
+	out := new(FightActionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FightList) DeepCopyObject() runtime.Object {
+func (in *FightActionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -84,56 +227,56 @@ func (in *FightList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FightSpec) DeepCopyInto(out *FightSpec) {
+func (in *FightActionSpec) DeepCopyInto(out *FightActionSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightSpec.
-func (in *FightSpec) DeepCopy() *FightSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightActionSpec.
+func (in *FightActionSpec) DeepCopy() *FightActionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FightSpec)
+	out := new(FightActionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FightStatus) DeepCopyInto(out *FightStatus) {
+func (in *FightActionStatus) DeepCopyInto(out *FightActionStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightStatus.
-func (in *FightStatus) DeepCopy() *FightStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightActionStatus.
+func (in *FightActionStatus) DeepCopy() *FightActionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FightStatus)
+	out := new(FightActionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeMon) DeepCopyInto(out *KubeMon) {
+func (in *FightGrant) DeepCopyInto(out *FightGrant) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMon.
-func (in *KubeMon) DeepCopy() *KubeMon {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightGrant.
+func (in *FightGrant) DeepCopy() *FightGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeMon)
+	out := new(FightGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeMon) DeepCopyObject() runtime.Object {
+func (in *FightGrant) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -141,31 +284,46 @@ func (in *KubeMon) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeMonList) DeepCopyInto(out *KubeMonList) {
+func (in *FightGrantFrom) DeepCopyInto(out *FightGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightGrantFrom.
+func (in *FightGrantFrom) DeepCopy() *FightGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(FightGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightGrantList) DeepCopyInto(out *FightGrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]KubeMon, len(*in))
+		*out = make([]FightGrant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonList.
-func (in *KubeMonList) DeepCopy() *KubeMonList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightGrantList.
+func (in *FightGrantList) DeepCopy() *FightGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeMonList)
+	out := new(FightGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *KubeMonList) DeepCopyObject() runtime.Object {
+func (in *FightGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -173,41 +331,1465 @@ func (in *KubeMonList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeMonSpec) DeepCopyInto(out *KubeMonSpec) {
+func (in *FightGrantSpec) DeepCopyInto(out *FightGrantSpec) {
 	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]FightGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.KubeMons != nil {
+		in, out := &in.KubeMons, &out.KubeMons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonSpec.
-func (in *KubeMonSpec) DeepCopy() *KubeMonSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightGrantSpec.
+func (in *FightGrantSpec) DeepCopy() *FightGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeMonSpec)
+	out := new(FightGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeMonStatus) DeepCopyInto(out *KubeMonStatus) {
+func (in *FightGrantStatus) DeepCopyInto(out *FightGrantStatus) {
 	*out = *in
-	if in.HP != nil {
-		in, out := &in.HP, &out.HP
-		*out = new(int32)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightGrantStatus.
+func (in *FightGrantStatus) DeepCopy() *FightGrantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FightGrantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightKubeMonState) DeepCopyInto(out *FightKubeMonState) {
+	*out = *in
+	out.KubeMonReference = in.KubeMonReference
+	out.Boosts = in.Boosts
+	if in.Moves != nil {
+		in, out := &in.Moves, &out.Moves
+		*out = make([]KubeMonMove, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ailment != nil {
+		in, out := &in.Ailment, &out.Ailment
+		*out = new(KubeMonAilment)
 		**out = **in
 	}
-	if in.Level != nil {
-		in, out := &in.Level, &out.Level
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightKubeMonState.
+func (in *FightKubeMonState) DeepCopy() *FightKubeMonState {
+	if in == nil {
+		return nil
+	}
+	out := new(FightKubeMonState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightList) DeepCopyInto(out *FightList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Fight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightList.
+func (in *FightList) DeepCopy() *FightList {
+	if in == nil {
+		return nil
+	}
+	out := new(FightList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FightList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightLogEntry) DeepCopyInto(out *FightLogEntry) {
+	*out = *in
+	if in.TargetHP != nil {
+		in, out := &in.TargetHP, &out.TargetHP
 		*out = new(int32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonStatus.
-func (in *KubeMonStatus) DeepCopy() *KubeMonStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightLogEntry.
+func (in *FightLogEntry) DeepCopy() *FightLogEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeMonStatus)
+	out := new(FightLogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightReference) DeepCopyInto(out *FightReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightReference.
+func (in *FightReference) DeepCopy() *FightReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FightReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightRewards) DeepCopyInto(out *FightRewards) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightRewards.
+func (in *FightRewards) DeepCopy() *FightRewards {
+	if in == nil {
+		return nil
+	}
+	out := new(FightRewards)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightSpec) DeepCopyInto(out *FightSpec) {
+	*out = *in
+	out.KubeMon1 = in.KubeMon1
+	out.KubeMon2 = in.KubeMon2
+	if in.TurnTimeoutSeconds != nil {
+		in, out := &in.TurnTimeoutSeconds, &out.TurnTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightSpec.
+func (in *FightSpec) DeepCopy() *FightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightStatus) DeepCopyInto(out *FightStatus) {
+	*out = *in
+	if in.TurnStartedAt != nil {
+		in, out := &in.TurnStartedAt, &out.TurnStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClaimedKubeMons != nil {
+		in, out := &in.ClaimedKubeMons, &out.ClaimedKubeMons
+		*out = make([]KubeMonReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTurn != nil {
+		in, out := &in.LastTurn, &out.LastTurn
+		*out = new(FightTurnResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Winner != nil {
+		in, out := &in.Winner, &out.Winner
+		*out = new(KubeMonReference)
+		**out = **in
+	}
+	if in.Loser != nil {
+		in, out := &in.Loser, &out.Loser
+		*out = new(KubeMonReference)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	out.Rewards = in.Rewards
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = make([]FightLogEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightStatus.
+func (in *FightStatus) DeepCopy() *FightStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FightStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FightTurnResult) DeepCopyInto(out *FightTurnResult) {
+	*out = *in
+	if in.KubeMons != nil {
+		in, out := &in.KubeMons, &out.KubeMons
+		*out = make([]FightKubeMonState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FightTurnResult.
+func (in *FightTurnResult) DeepCopy() *FightTurnResult {
+	if in == nil {
+		return nil
+	}
+	out := new(FightTurnResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Habitat) DeepCopyInto(out *Habitat) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Habitat.
+func (in *Habitat) DeepCopy() *Habitat {
+	if in == nil {
+		return nil
+	}
+	out := new(Habitat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Habitat) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatList) DeepCopyInto(out *HabitatList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Habitat, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatList.
+func (in *HabitatList) DeepCopy() *HabitatList {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HabitatList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatSpec) DeepCopyInto(out *HabitatSpec) {
+	*out = *in
+	if in.Species != nil {
+		in, out := &in.Species, &out.Species
+		*out = make([]HabitatSpecies, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SpawnInterval != nil {
+		in, out := &in.SpawnInterval, &out.SpawnInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.DespawnAfter != nil {
+		in, out := &in.DespawnAfter, &out.DespawnAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatSpec.
+func (in *HabitatSpec) DeepCopy() *HabitatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatSpecies) DeepCopyInto(out *HabitatSpecies) {
+	*out = *in
+	if in.Moves != nil {
+		in, out := &in.Moves, &out.Moves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatSpecies.
+func (in *HabitatSpecies) DeepCopy() *HabitatSpecies {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatSpecies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HabitatStatus) DeepCopyInto(out *HabitatStatus) {
+	*out = *in
+	if in.LastSpawn != nil {
+		in, out := &in.LastSpawn, &out.LastSpawn
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HabitatStatus.
+func (in *HabitatStatus) DeepCopy() *HabitatStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HabitatStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Inventory) DeepCopyInto(out *Inventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Inventory.
+func (in *Inventory) DeepCopy() *Inventory {
+	if in == nil {
+		return nil
+	}
+	out := new(Inventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Inventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryList) DeepCopyInto(out *InventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Inventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryList.
+func (in *InventoryList) DeepCopy() *InventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventorySpec) DeepCopyInto(out *InventorySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventorySpec.
+func (in *InventorySpec) DeepCopy() *InventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryStatus) DeepCopyInto(out *InventoryStatus) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ItemStack, len(*in))
+		copy(*out, *in)
+	}
+	if in.Receipts != nil {
+		in, out := &in.Receipts, &out.Receipts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryStatus.
+func (in *InventoryStatus) DeepCopy() *InventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Item) DeepCopyInto(out *Item) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Item.
+func (in *Item) DeepCopy() *Item {
+	if in == nil {
+		return nil
+	}
+	out := new(Item)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Item) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemList) DeepCopyInto(out *ItemList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Item, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemList.
+func (in *ItemList) DeepCopy() *ItemList {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ItemList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemSpec) DeepCopyInto(out *ItemSpec) {
+	*out = *in
+	if in.Cures != nil {
+		in, out := &in.Cures, &out.Cures
+		*out = make([]Ailment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemSpec.
+func (in *ItemSpec) DeepCopy() *ItemSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemStack) DeepCopyInto(out *ItemStack) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStack.
+func (in *ItemStack) DeepCopy() *ItemStack {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemStack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ItemStatus) DeepCopyInto(out *ItemStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ItemStatus.
+func (in *ItemStatus) DeepCopy() *ItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMon) DeepCopyInto(out *KubeMon) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMon.
+func (in *KubeMon) DeepCopy() *KubeMon {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMon)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeMon) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonAilment) DeepCopyInto(out *KubeMonAilment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonAilment.
+func (in *KubeMonAilment) DeepCopy() *KubeMonAilment {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonAilment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonBoosts) DeepCopyInto(out *KubeMonBoosts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonBoosts.
+func (in *KubeMonBoosts) DeepCopy() *KubeMonBoosts {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonBoosts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonList) DeepCopyInto(out *KubeMonList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubeMon, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonList.
+func (in *KubeMonList) DeepCopy() *KubeMonList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubeMonList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonMove) DeepCopyInto(out *KubeMonMove) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonMove.
+func (in *KubeMonMove) DeepCopy() *KubeMonMove {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonMove)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonReference) DeepCopyInto(out *KubeMonReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonReference.
+func (in *KubeMonReference) DeepCopy() *KubeMonReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonSpec) DeepCopyInto(out *KubeMonSpec) {
+	*out = *in
+	if in.Moves != nil {
+		in, out := &in.Moves, &out.Moves
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitialLevel != nil {
+		in, out := &in.InitialLevel, &out.InitialLevel
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonSpec.
+func (in *KubeMonSpec) DeepCopy() *KubeMonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeMonStatus) DeepCopyInto(out *KubeMonStatus) {
+	*out = *in
+	if in.HP != nil {
+		in, out := &in.HP, &out.HP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = new(int32)
+		**out = **in
+	}
+	if in.XP != nil {
+		in, out := &in.XP, &out.XP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHP != nil {
+		in, out := &in.MaxHP, &out.MaxHP
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Attack != nil {
+		in, out := &in.Attack, &out.Attack
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Defense != nil {
+		in, out := &in.Defense, &out.Defense
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Speed != nil {
+		in, out := &in.Speed, &out.Speed
+		*out = new(int32)
+		**out = **in
+	}
+	out.Boosts = in.Boosts
+	if in.Moves != nil {
+		in, out := &in.Moves, &out.Moves
+		*out = make([]KubeMonMove, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ailment != nil {
+		in, out := &in.Ailment, &out.Ailment
+		*out = new(KubeMonAilment)
+		**out = **in
+	}
+	if in.Friendship != nil {
+		in, out := &in.Friendship, &out.Friendship
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FriendshipHealedAt != nil {
+		in, out := &in.FriendshipHealedAt, &out.FriendshipHealedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousSpecies != nil {
+		in, out := &in.PreviousSpecies, &out.PreviousSpecies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CurrentFight != nil {
+		in, out := &in.CurrentFight, &out.CurrentFight
+		*out = new(FightReference)
+		**out = **in
+	}
+	if in.FightTurn != nil {
+		in, out := &in.FightTurn, &out.FightTurn
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeMonStatus.
+func (in *KubeMonStatus) DeepCopy() *KubeMonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeMonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Move) DeepCopyInto(out *Move) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Move.
+func (in *Move) DeepCopy() *Move {
+	if in == nil {
+		return nil
+	}
+	out := new(Move)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Move) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MoveList) DeepCopyInto(out *MoveList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Move, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoveList.
+func (in *MoveList) DeepCopy() *MoveList {
+	if in == nil {
+		return nil
+	}
+	out := new(MoveList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MoveList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MoveSpec) DeepCopyInto(out *MoveSpec) {
+	*out = *in
+	if in.AilmentChance != nil {
+		in, out := &in.AilmentChance, &out.AilmentChance
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoveSpec.
+func (in *MoveSpec) DeepCopy() *MoveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MoveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MoveStatus) DeepCopyInto(out *MoveStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoveStatus.
+func (in *MoveStatus) DeepCopy() *MoveStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MoveStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Purchase) DeepCopyInto(out *Purchase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Purchase.
+func (in *Purchase) DeepCopy() *Purchase {
+	if in == nil {
+		return nil
+	}
+	out := new(Purchase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Purchase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurchaseList) DeepCopyInto(out *PurchaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Purchase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurchaseList.
+func (in *PurchaseList) DeepCopy() *PurchaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(PurchaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PurchaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurchaseSpec) DeepCopyInto(out *PurchaseSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurchaseSpec.
+func (in *PurchaseSpec) DeepCopy() *PurchaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PurchaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PurchaseStatus) DeepCopyInto(out *PurchaseStatus) {
+	*out = *in
+	if in.ReservedAt != nil {
+		in, out := &in.ReservedAt, &out.ReservedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PurchaseStatus.
+func (in *PurchaseStatus) DeepCopy() *PurchaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PurchaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Shop) DeepCopyInto(out *Shop) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Shop.
+func (in *Shop) DeepCopy() *Shop {
+	if in == nil {
+		return nil
+	}
+	out := new(Shop)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Shop) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShopItem) DeepCopyInto(out *ShopItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShopItem.
+func (in *ShopItem) DeepCopy() *ShopItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ShopItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShopList) DeepCopyInto(out *ShopList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Shop, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShopList.
+func (in *ShopList) DeepCopy() *ShopList {
+	if in == nil {
+		return nil
+	}
+	out := new(ShopList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ShopList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShopSpec) DeepCopyInto(out *ShopSpec) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ShopItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestockInterval != nil {
+		in, out := &in.RestockInterval, &out.RestockInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShopSpec.
+func (in *ShopSpec) DeepCopy() *ShopSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ShopSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShopStatus) DeepCopyInto(out *ShopStatus) {
+	*out = *in
+	if in.Stock != nil {
+		in, out := &in.Stock, &out.Stock
+		*out = make([]ItemStack, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sold != nil {
+		in, out := &in.Sold, &out.Sold
+		*out = make([]ItemStack, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]types.UID, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRestock != nil {
+		in, out := &in.LastRestock, &out.LastRestock
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShopStatus.
+func (in *ShopStatus) DeepCopy() *ShopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Species) DeepCopyInto(out *Species) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Species.
+func (in *Species) DeepCopy() *Species {
+	if in == nil {
+		return nil
+	}
+	out := new(Species)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Species) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeciesList) DeepCopyInto(out *SpeciesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Species, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeciesList.
+func (in *SpeciesList) DeepCopy() *SpeciesList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeciesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpeciesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeciesSpec) DeepCopyInto(out *SpeciesSpec) {
+	*out = *in
+	if in.Evolutions != nil {
+		in, out := &in.Evolutions, &out.Evolutions
+		*out = make([]Evolution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeciesSpec.
+func (in *SpeciesSpec) DeepCopy() *SpeciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeciesStatus) DeepCopyInto(out *SpeciesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeciesStatus.
+func (in *SpeciesStatus) DeepCopy() *SpeciesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeciesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Trainer) DeepCopyInto(out *Trainer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Trainer.
+func (in *Trainer) DeepCopy() *Trainer {
+	if in == nil {
+		return nil
+	}
+	out := new(Trainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Trainer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainerList) DeepCopyInto(out *TrainerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Trainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainerList.
+func (in *TrainerList) DeepCopy() *TrainerList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrainerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainerSpec) DeepCopyInto(out *TrainerSpec) {
+	*out = *in
+	if in.Badges != nil {
+		in, out := &in.Badges, &out.Badges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Party != nil {
+		in, out := &in.Party, &out.Party
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainerSpec.
+func (in *TrainerSpec) DeepCopy() *TrainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrainerStatus) DeepCopyInto(out *TrainerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrainerStatus.
+func (in *TrainerStatus) DeepCopy() *TrainerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrainerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Transaction) DeepCopyInto(out *Transaction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transaction.
+func (in *Transaction) DeepCopy() *Transaction {
+	if in == nil {
+		return nil
+	}
+	out := new(Transaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Transaction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionList) DeepCopyInto(out *TransactionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Transaction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionList.
+func (in *TransactionList) DeepCopy() *TransactionList {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TransactionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionSpec) DeepCopyInto(out *TransactionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionSpec.
+func (in *TransactionSpec) DeepCopy() *TransactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransactionStatus) DeepCopyInto(out *TransactionStatus) {
+	*out = *in
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransactionStatus.
+func (in *TransactionStatus) DeepCopy() *TransactionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransactionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -17,9 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"math"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -28,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -36,6 +41,8 @@ import (
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
 	"github.com/memeToasty/kubemon/internal/controller"
+	"github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -57,6 +64,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var fightTTLAfterFinished time.Duration
+	var typeChartPath string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
@@ -66,6 +75,12 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.DurationVar(&fightTTLAfterFinished, "fight-ttl-after-finished", 0,
+		"Time after which ended Fights without their own ttlSecondsAfterFinished are deleted, e.g. 24h. "+
+			"0 keeps them forever.")
+	flag.StringVar(&typeChartPath, "type-chart", "",
+		"Path to a YAML file with the type chart deciding how effective Moves are. "+
+			"If empty, the built-in type chart is used.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -122,20 +137,106 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controller.SetupFieldIndexes(context.Background(), mgr); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
+
+	if err = metrics.RegisterKubeMonCollector(mgr.GetCache()); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
 	if err = (&controller.KubeMonReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("kubemon-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeMon")
 		os.Exit(1)
 	}
+	typeChart, err := kubemon.LoadTypeChart(typeChartPath)
+	if err != nil {
+		setupLog.Error(err, "unable to load type chart", "path", typeChartPath)
+		os.Exit(1)
+	}
+	var defaultFightTTL *int32
+	if fightTTLAfterFinished < 0 || fightTTLAfterFinished.Seconds() > math.MaxInt32 {
+		setupLog.Error(fmt.Errorf("must be between 0 and %d seconds", math.MaxInt32), "invalid --fight-ttl-after-finished", "value", fightTTLAfterFinished)
+		os.Exit(1)
+	}
+	if fightTTLAfterFinished > 0 {
+		defaultFightTTL = ptr.To(int32(fightTTLAfterFinished / time.Second))
+	}
 	if err = (&controller.FightReconciler{
+		Client:                         mgr.GetClient(),
+		Scheme:                         mgr.GetScheme(),
+		APIReader:                      mgr.GetAPIReader(),
+		DefaultTTLSecondsAfterFinished: defaultFightTTL,
+		Recorder:                       mgr.GetEventRecorderFor("fight-controller"),
+		TypeChart:                      typeChart,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Fight")
+		os.Exit(1)
+	}
+	if err = (&controller.TransactionReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Transaction")
+		os.Exit(1)
+	}
+	if err = (&controller.ShopReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Fight")
+		setupLog.Error(err, "unable to create controller", "controller", "Shop")
+		os.Exit(1)
+	}
+	if err = (&controller.PurchaseReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Purchase")
+		os.Exit(1)
+	}
+	if err = (&controller.HabitatReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Habitat")
+		os.Exit(1)
+	}
+	if err = (&controller.CatchAttemptReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CatchAttempt")
+		os.Exit(1)
+	}
+	if err = (&controller.TrainerReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Trainer")
 		os.Exit(1)
 	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&kubemonv1.KubeMon{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KubeMon")
+			os.Exit(1)
+		}
+		if err = (&kubemonv1.Fight{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Fight")
+			os.Exit(1)
+		}
+		if err = (&kubemonv1.Transaction{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Transaction")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,207 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+var (
+	CatchMessageTrainerNotFound = "Trainer %s does not exist"
+	CatchMessageNoBall          = "%s is not a Ball"
+	CatchMessageMonNotFound     = "Could not find KubeMon %s"
+	CatchMessageNotWild         = "%s is not wild"
+	CatchMessageFainted         = "%s has fainted and cannot be caught"
+	CatchMessageNoBallLeft      = "Trainer %s has no %s left"
+	CatchMessageThrown          = "%s threw %s at %s"
+	CatchMessageCaught          = "%s caught %s"
+	CatchMessageEscaped         = "%s broke free"
+	CatchMessageFled            = "%s fled"
+)
+
+// CatchAttemptReconciler reconciles a CatchAttempt object
+type CatchAttemptReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=catchattempts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=catchattempts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=catchattempts/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=items,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories/status,verbs=get;update;patch
+
+func (r *CatchAttemptReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var attempt kubemonv1.CatchAttempt
+	if err := r.Get(ctx, req.NamespacedName, &attempt); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find CatchAttempt")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if attempt.DeletionTimestamp != nil {
+		log.V(1).Info("CatchAttempt is marked for deletion, stop reconciling")
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	var err error
+	switch attempt.Status.Phase {
+	case "":
+		err = r.throw(ctx, &attempt)
+	case kubemonv1.CatchAttemptThrown:
+		err = r.resolve(ctx, &attempt)
+	default:
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "Could not process CatchAttempt", "Phase", attempt.Status.Phase)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// throw takes the Ball out of the Inventory of the Trainer and records the chance of catching the KubeMon
+func (r *CatchAttemptReconciler) throw(ctx context.Context, attempt *kubemonv1.CatchAttempt) error {
+	spec := attempt.Spec
+
+	var trainer kubemonv1.Trainer
+	err := r.Get(ctx, types.NamespacedName{Namespace: attempt.Namespace, Name: spec.Trainer}, &trainer)
+	if apierrors.IsNotFound(err) {
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageTrainerNotFound, spec.Trainer))
+	} else if err != nil {
+		return err
+	}
+
+	var ball kubemonv1.Item
+	err = r.Get(ctx, types.NamespacedName{Name: spec.Ball}, &ball)
+	if apierrors.IsNotFound(err) || (err == nil && ball.Spec.Category != kubemonv1.ItemCategoryBall) {
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageNoBall, spec.Ball))
+	} else if err != nil {
+		return err
+	}
+
+	mon, err := r.getKubeMon(ctx, attempt)
+	if err != nil {
+		if apierrors.IsNotFound(err) || err == kubemon.ErrSpeciesNotFound {
+			return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageMonNotFound, spec.KubeMon))
+		}
+		return err
+	}
+	if mon.Owner() != "" {
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageNotWild, spec.KubeMon))
+	}
+	if mon.IsDead() {
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageFainted, spec.KubeMon))
+	}
+
+	// The Ball is taken with the UID of the CatchAttempt as receipt, so retrying after the phase could not be recorded does not take another one
+	if err := takeItem(ctx, r.Client, attempt.Namespace, spec.Trainer, spec.Ball, string(attempt.UID)); err != nil {
+		if err == ErrItemNotInInventory {
+			return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptFailed, fmt.Sprintf(CatchMessageNoBallLeft, spec.Trainer, spec.Ball))
+		}
+		return err
+	}
+
+	attempt.Status.Chance = mon.CatchChance(&ball)
+	return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptThrown, fmt.Sprintf(CatchMessageThrown, spec.Trainer, spec.Ball, spec.KubeMon))
+}
+
+// resolve decides whether the KubeMon is caught. The outcome is derived from the UID of the CatchAttempt,
+// so it stays the same if resolving has to be retried.
+func (r *CatchAttemptReconciler) resolve(ctx context.Context, attempt *kubemonv1.CatchAttempt) error {
+	spec := attempt.Spec
+
+	// The throw has been recorded, so the receipt of the Ball is no longer needed
+	if err := forgetReceipt(ctx, r.Client, attempt.Namespace, spec.Trainer, string(attempt.UID)); err != nil {
+		return err
+	}
+
+	mon, err := r.getKubeMon(ctx, attempt)
+	if err != nil {
+		if apierrors.IsNotFound(err) || err == kubemon.ErrSpeciesNotFound {
+			return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptEscaped, fmt.Sprintf(CatchMessageFled, spec.KubeMon))
+		}
+		return err
+	}
+
+	switch mon.Owner() {
+	case spec.Trainer:
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptCaught, fmt.Sprintf(CatchMessageCaught, spec.Trainer, spec.KubeMon))
+	case "":
+	default:
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptEscaped, fmt.Sprintf(CatchMessageNotWild, spec.KubeMon))
+	}
+
+	if catchRoll(attempt) >= attempt.Status.Chance {
+		return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptEscaped, fmt.Sprintf(CatchMessageEscaped, spec.KubeMon))
+	}
+
+	if err := mon.Catch(spec.Trainer); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("KubeMon was caught", "KubeMon", spec.KubeMon, "Trainer", spec.Trainer)
+	return r.setPhase(ctx, attempt, kubemonv1.CatchAttemptCaught, fmt.Sprintf(CatchMessageCaught, spec.Trainer, spec.KubeMon))
+}
+
+func (r *CatchAttemptReconciler) getKubeMon(ctx context.Context, attempt *kubemonv1.CatchAttempt) (*kubemon.KubeMon, error) {
+	apiMon := &kubemonv1.KubeMon{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: attempt.Namespace, Name: attempt.Spec.KubeMon}, apiMon); err != nil {
+		return nil, err
+	}
+	return kubemon.New(ctx, r.Client, r.Status(), apiMon)
+}
+
+func (r *CatchAttemptReconciler) setPhase(ctx context.Context, attempt *kubemonv1.CatchAttempt, phase kubemonv1.CatchAttemptPhase, message string) error {
+	attempt.Status.Phase = phase
+	attempt.Status.Message = message
+	return r.Status().Update(ctx, attempt)
+}
+
+// catchRoll returns a number between 0 and 99 derived from the UID of the CatchAttempt
+func catchRoll(attempt *kubemonv1.CatchAttempt) int32 {
+	h := fnv.New64a()
+	h.Write([]byte(attempt.UID))
+	return rand.New(rand.NewSource(int64(h.Sum64()))).Int31n(100)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CatchAttemptReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.CatchAttempt{}).
+		Complete(r)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var _ = Describe("CatchAttempt Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		attempt := &kubemonv1.CatchAttempt{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind CatchAttempt")
+			err := k8sClient.Get(ctx, typeNamespacedName, attempt)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &kubemonv1.CatchAttempt{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: kubemonv1.CatchAttemptSpec{
+						Trainer: "missing-trainer",
+						KubeMon: "kubemon-sample1",
+						Ball:    "ball",
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &kubemonv1.CatchAttempt{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance CatchAttempt")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &CatchAttemptReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Failing the CatchAttempt of a missing Trainer")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, attempt)).To(Succeed())
+			Expect(attempt.Status.Phase).To(Equal(kubemonv1.CatchAttemptFailed))
+		})
+	})
+
+	Context("When catching a wild KubeMon", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "catch-wild-mon", Namespace: "default"}
+
+		It("should take the Ball once and make the Trainer the owner of the KubeMon", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.CatchAttempt{}, &kubemonv1.KubeMon{}, &kubemonv1.Inventory{}).
+				WithObjects(
+					&kubemonv1.Species{
+						ObjectMeta: metav1.ObjectMeta{Name: "catch-species"},
+						Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45, CatchRate: 255},
+					},
+					&kubemonv1.Item{
+						ObjectMeta: metav1.ObjectMeta{Name: "ultra-ball"},
+						Spec:       kubemonv1.ItemSpec{Category: kubemonv1.ItemCategoryBall, CatchBonus: 300},
+					},
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "ash", Namespace: "default"}},
+					&kubemonv1.Inventory{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash", Namespace: "default"},
+						Status:     kubemonv1.InventoryStatus{Items: []kubemonv1.ItemStack{{Name: "ultra-ball", Quantity: 2}}},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: "wild-mon", Namespace: "default", Labels: map[string]string{kubemonv1.HabitatLabel: "meadow"}},
+						Spec:       kubemonv1.KubeMonSpec{Species: "catch-species", Strength: 1},
+					},
+					&kubemonv1.CatchAttempt{
+						ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace, UID: "catch-wild-mon-uid"},
+						Spec:       kubemonv1.CatchAttemptSpec{Trainer: "ash", KubeMon: "wild-mon", Ball: "ultra-ball"},
+					},
+				).
+				Build()
+
+			r := &CatchAttemptReconciler{Client: c, Scheme: c.Scheme()}
+			reconcileAttempt := func() (*kubemonv1.CatchAttempt, *kubemonv1.Inventory) {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+				Expect(err).NotTo(HaveOccurred())
+
+				attempt := &kubemonv1.CatchAttempt{}
+				Expect(c.Get(ctx, name, attempt)).To(Succeed())
+				inventory := &kubemonv1.Inventory{}
+				Expect(c.Get(ctx, types.NamespacedName{Name: "ash", Namespace: "default"}, inventory)).To(Succeed())
+				return attempt, inventory
+			}
+
+			By("throwing the Ball")
+			attempt, inventory := reconcileAttempt()
+			Expect(attempt.Status.Phase).To(Equal(kubemonv1.CatchAttemptThrown))
+			Expect(attempt.Status.Chance).To(Equal(int32(100)))
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "ultra-ball", Quantity: 1}))
+			Expect(inventory.Status.Receipts).To(ConsistOf(string(attempt.UID)))
+
+			By("catching the KubeMon")
+			attempt, inventory = reconcileAttempt()
+			Expect(attempt.Status.Phase).To(Equal(kubemonv1.CatchAttemptCaught))
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "ultra-ball", Quantity: 1}))
+			Expect(inventory.Status.Receipts).To(BeEmpty())
+
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "wild-mon", Namespace: "default"}, mon)).To(Succeed())
+			Expect(mon.Spec.Owner).To(Equal("ash"))
+			Expect(mon.Labels).NotTo(HaveKey(kubemonv1.HabitatLabel))
+
+			By("leaving the finished CatchAttempt alone")
+			attempt, _ = reconcileAttempt()
+			Expect(attempt.Status.Phase).To(Equal(kubemonv1.CatchAttemptCaught))
+		})
+	})
+})
@@ -0,0 +1,47 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+// Reasons of the Events emitted for game actions
+const (
+	EventReasonHealed               = "Healed"
+	EventReasonAttack               = "Attack"
+	EventReasonFainted              = "Fainted"
+	EventReasonLevelUp              = "LevelUp"
+	EventReasonEvolved              = "Evolved"
+	EventReasonFightStarted         = "FightStarted"
+	EventReasonFightFinished        = "FightFinished"
+	EventReasonFightAborted         = "FightAborted"
+	EventReasonParticipantsNotReady = "ParticipantsNotReady"
+)
+
+// recordEvent emits the Event on the object and on all given KubeMons, skipping KubeMons which could not be loaded
+func recordEvent(recorder record.EventRecorder, object runtime.Object, mons []*kubemon.KubeMon, eventtype, reason, message string) {
+	recorder.Event(object, eventtype, reason, message)
+	for _, mon := range mons {
+		if mon != nil {
+			recorder.Event(mon.Object(), eventtype, reason, message)
+		}
+	}
+}
@@ -19,32 +19,80 @@ package controller
 import (
 	"context"
 	"fmt"
-
+	"math/rand"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
 
 	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
 )
 
 // FightReconciler reconciles a Fight object
 type FightReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// APIReader reads the Fights holding a KubeMon without the cache, so recently started Fights are not missed
+	APIReader client.Reader
+	// DefaultTTLSecondsAfterFinished is used for Fights without a ttlSecondsAfterFinished.
+	// If nil, such Fights are kept forever.
+	DefaultTTLSecondsAfterFinished *int32
+	Recorder                       record.EventRecorder
+	// TypeChart decides how effective Moves are against the types of their targets.
+	// If nil, all Moves are neutral.
+	TypeChart kubemon.TypeChart
 }
 
 var (
-	FightMessageMonNotFound = "Could not find KubeMon %s"
+	FightMessageMonNotFound        = "Could not find KubeMon %s"
+	FightMessageMonSpeciesNotFound = "Species of KubeMon %s does not exist"
+	FightMessageSwitch             = "%s was switched out for %s"
+	FightMessageItem               = "%s used %s on %s"
+	FightMessageInvalidAction      = "%s could not %s, using the default action instead"
+	FightMessageNotGranted         = "KubeMon %s may not fight, as no FightGrant in its namespace allows it"
+	FightMessageMonInBattle        = "KubeMon %s is already fighting in Fight %s"
+	FightMessageStarted            = "%s and %s started fighting"
+	FightMessageFinished           = "%s won against %s"
+	FightMessageForfeit            = "%s forfeited the Fight"
+	FightMessageBothForfeit        = "%s and %s both forfeited the Fight"
+	FightMessageFainted            = "%s fainted"
+	FightMessageBothFainted        = "%s and %s both fainted, the Fight ends in a draw"
+	FightMessageLevelUp            = "%s grew to level %d"
+	FightMessageAsleep             = "%s is fast asleep"
+	FightMessageWokeUp             = "%s woke up"
+	FightMessageParalyzed          = "%s is paralyzed and cannot move"
+	FightMessageAilmentDamage      = "%s is hurt by its %s and lost %d HP (%d HP left)"
 )
 
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fights,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fights/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fights/finalizers,verbs=update
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=moves,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fightactions,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=items,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fightgrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *FightReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -68,94 +116,546 @@ func (r *FightReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 	log.Info("Fight not marked for deletion")
 
-	fightNamespace := fight.Namespace
-
-	mon1Name := types.NamespacedName{
-		Namespace: fightNamespace,
-		Name:      fight.Spec.KubeMon1,
+	if !fight.IsActive() {
+		if err := r.releaseKubeMons(ctx, &fight); err != nil {
+			log.Error(err, "Could not release KubeMons of Fight")
+			return ctrl.Result{}, err
+		}
 	}
 
-	mon2Name := types.NamespacedName{
-		Namespace: fightNamespace,
-		Name:      fight.Spec.KubeMon2,
+	switch fight.Status.Phase {
+	case kubemonv1.FightFinished:
+		if meta.IsStatusConditionPresentAndEqual(fight.Status.Conditions, kubemonv1.FightConditionRewarded, metav1.ConditionUnknown) {
+			return r.rewardFight(ctx, &fight)
+		}
+		return r.cleanupFight(ctx, &fight)
+	case kubemonv1.FightAborted:
+		return r.cleanupFight(ctx, &fight)
 	}
 
-	mon1, err := r.getKubeMon(ctx, mon1Name)
+	mons, reason, message, err := r.getParticipants(ctx, &fight)
 	if err != nil {
-		if client.IgnoreNotFound(err) == nil {
-			if err := r.updateStatusMessage(ctx, &fight, fmt.Sprintf(FightMessageMonNotFound, mon1Name)); err != nil {
-				return ctrl.Result{}, err
-			}
-		}
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		log.Error(err, "Could not get KubeMons of Fight")
+		return ctrl.Result{}, err
 	}
-	mon2, err := r.getKubeMon(ctx, mon2Name)
-	if err != nil {
-		if client.IgnoreNotFound(err) == nil {
-			if err := r.updateStatusMessage(ctx, &fight, fmt.Sprintf(FightMessageMonNotFound, mon2Name)); err != nil {
-				return ctrl.Result{}, err
-			}
+	if message != "" {
+		if fight.Status.Phase == kubemonv1.FightInProgress {
+			// KubeMons which could not be found are left out of the events
+			found := slices.DeleteFunc(slices.Clone(mons[:]), func(mon *kubemon.KubeMon) bool { return mon == nil })
+			return ctrl.Result{}, r.abortFight(ctx, &fight, message, found...)
 		}
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{}, r.waitForParticipants(ctx, &fight, reason, message, mons[:])
 	}
+	mon1, mon2 := mons[0], mons[1]
 
-	// Both KubeMons exist
+	if fight.Status.Seed == nil {
+		fight.Status.Seed = fight.Spec.Seed
+		if fight.Status.Seed == nil {
+			fight.Status.Seed = ptr.To(rand.Int63())
+		}
+	}
 
-	// Death logic
-	if mon1.IsDead() {
-		if err := mon2.LevelUp(); err != nil {
-			log.Error(err, "Could not level up KubeMon", "KubeMon", mon2.Name())
+	if fight.Status.Phase != kubemonv1.FightInProgress {
+		log.Info("Starting Fight")
+		fight.Status.Phase = kubemonv1.FightInProgress
+		fight.Status.StartedAt = ptr.To(metav1.Now())
+		meta.SetStatusCondition(&fight.Status.Conditions, metav1.Condition{
+			Type:               kubemonv1.FightConditionParticipantsReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             "KubeMonsReady",
+			Message:            "Both KubeMons are ready to fight",
+			ObservedGeneration: fight.Generation,
+		})
+		message := fmt.Sprintf(FightMessageStarted, mon1.Name(), mon2.Name())
+		if err := r.appendFightLog(ctx, &fight, kubemonv1.FightLogEntry{Turn: fight.Status.TurnNumber, Message: message}); err != nil {
+			log.Error(err, "Could not write log of Fight")
 			return ctrl.Result{}, err
 		}
-
-		if err := r.Delete(ctx, &fight); err != nil {
-			log.Error(err, "Could not delete Fight")
+		if err := r.Status().Update(ctx, &fight); err != nil {
+			log.Error(err, "Could not update status of Fight")
 			return ctrl.Result{}, err
 		}
+		recordEvent(r.Recorder, &fight, []*kubemon.KubeMon{mon1, mon2}, corev1.EventTypeNormal, EventReasonFightStarted, message)
+		metrics.FightsStarted.WithLabelValues(string(fight.Spec.Mode)).Inc()
+	}
 
-		return ctrl.Result{}, nil
+	mons, err = r.applyLastTurn(ctx, &fight, mons)
+	if err != nil {
+		log.Error(err, "Could not apply last turn of Fight")
+		return ctrl.Result{}, err
+	}
+	mon1, mon2 = mons[0], mons[1]
+
+	// Death logic
+	if mon1.IsDead() && mon2.IsDead() {
+		return ctrl.Result{}, r.finishDraw(ctx, &fight, mon1, mon2)
+	}
+
+	if mon1.IsDead() {
+		return r.finishFight(ctx, &fight, mon2, mon1)
 	}
 
 	if mon2.IsDead() {
-		if err := mon1.LevelUp(); err != nil {
-			log.Error(err, "Could not level up KubeMon", "KubeMon", mon1.Name())
+		return r.finishFight(ctx, &fight, mon1, mon2)
+	}
+
+	if fight.Spec.Mode == kubemonv1.FightModeInteractive {
+		return r.reconcileInteractive(ctx, &fight, mon1, mon2)
+	}
+
+	entries, err := r.resolveTurn(ctx, &fight, []*combatant{{side: 1, mon: mon1.Simulate()}, {side: 2, mon: mon2.Simulate()}})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.appendFightLog(ctx, &fight, entries...); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return ctrl.Result{}, err
+	}
+
+	fight.Status.TurnNumber += 1
+	if err := r.Status().Update(ctx, &fight); err != nil {
+		log.Error(err, "Could not update status of Fight")
+
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Got through reconcile! requeuing")
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// finishFight records the outcome of the Fight and rewards the winner
+func (r *FightReconciler) finishFight(ctx context.Context, fight *kubemonv1.Fight, winner, loser *kubemon.KubeMon) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	if err := r.deleteFightActions(ctx, fight, nil); err != nil {
+		log.Error(err, "Could not delete FightActions of Fight")
+		return ctrl.Result{}, err
+	}
+
+	fight.Status.Phase = kubemonv1.FightFinished
+	fight.Status.Winner = &kubemonv1.KubeMonReference{Name: winner.Name(), Namespace: winner.Namespace()}
+	fight.Status.Loser = &kubemonv1.KubeMonReference{Name: loser.Name(), Namespace: loser.Namespace()}
+	fight.Status.FinishedAt = ptr.To(metav1.Now())
+	message := fmt.Sprintf(FightMessageFinished, winner.Name(), loser.Name())
+	if err := r.appendFightLog(ctx, fight, kubemonv1.FightLogEntry{
+		Turn:    fight.Status.TurnNumber,
+		Actor:   winner.Name(),
+		Target:  loser.Name(),
+		Message: message,
+	}); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return ctrl.Result{}, err
+	}
+	meta.SetStatusCondition(&fight.Status.Conditions, metav1.Condition{
+		Type:               kubemonv1.FightConditionRewarded,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "RewardPending",
+		Message:            "The winner has not been rewarded yet",
+		ObservedGeneration: fight.Generation,
+	})
+	if err := r.Status().Update(ctx, fight); err != nil {
+		log.Error(err, "Could not update status of Fight")
+		return ctrl.Result{}, err
+	}
+	log.Info("Fight finished", "Winner", winner.Name(), "Loser", loser.Name())
+	observeFightEnded(fight)
+	if loser.IsDead() {
+		recordEvent(r.Recorder, fight, []*kubemon.KubeMon{loser}, corev1.EventTypeNormal, EventReasonFainted, fmt.Sprintf(FightMessageFainted, loser.Name()))
+	}
+	recordEvent(r.Recorder, fight, []*kubemon.KubeMon{winner, loser}, corev1.EventTypeNormal, EventReasonFightFinished, message)
+
+	return r.rewardFight(ctx, fight)
+}
+
+// finishDraw finishes the Fight without a winner, once both KubeMons fainted in the same turn. Draws are not rewarded.
+func (r *FightReconciler) finishDraw(ctx context.Context, fight *kubemonv1.Fight, mon1, mon2 *kubemon.KubeMon) error {
+	log := log.FromContext(ctx)
+
+	if err := r.deleteFightActions(ctx, fight, nil); err != nil {
+		log.Error(err, "Could not delete FightActions of Fight")
+		return err
+	}
+
+	fight.Status.Phase = kubemonv1.FightFinished
+	fight.Status.Draw = true
+	fight.Status.FinishedAt = ptr.To(metav1.Now())
+	message := fmt.Sprintf(FightMessageBothFainted, mon1.Name(), mon2.Name())
+	if err := r.appendFightLog(ctx, fight, kubemonv1.FightLogEntry{Turn: fight.Status.TurnNumber, Message: message}); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return err
+	}
+	if err := r.setRewarded(ctx, fight, metav1.ConditionFalse, "Draw", "A draw has no winner to reward"); err != nil {
+		log.Error(err, "Could not update status of Fight")
+		return err
+	}
+	log.Info("Fight finished in a draw")
+	observeFightEnded(fight)
+	for _, mon := range []*kubemon.KubeMon{mon1, mon2} {
+		recordEvent(r.Recorder, fight, []*kubemon.KubeMon{mon}, corev1.EventTypeNormal, EventReasonFainted, fmt.Sprintf(FightMessageFainted, mon.Name()))
+	}
+	recordEvent(r.Recorder, fight, []*kubemon.KubeMon{mon1, mon2}, corev1.EventTypeNormal, EventReasonFightFinished, message)
+	return nil
+}
+
+// rewardFight pays out the Trainer of the winner of a finished Fight and awards experience to the winner.
+// Payouts are validated against the outcome of the Fight, so the experience is only awarded once the payout has been processed.
+// Every other reward is marked in the status of the Fight before it is handed out, so retries skip the rewards already handed out.
+func (r *FightReconciler) rewardFight(ctx context.Context, fight *kubemonv1.Fight) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	winner, err := r.getKubeMon(ctx, kubeMonKey(fight.Status.Winner))
+	if err != nil && !apierrors.IsNotFound(err) && err != kubemon.ErrSpeciesNotFound {
+		return ctrl.Result{}, err
+	}
+	loser, lerr := r.getKubeMon(ctx, kubeMonKey(fight.Status.Loser))
+	if lerr != nil && !apierrors.IsNotFound(lerr) && lerr != kubemon.ErrSpeciesNotFound {
+		return ctrl.Result{}, lerr
+	}
+	if err != nil || lerr != nil {
+		return ctrl.Result{}, r.setRewarded(ctx, fight, metav1.ConditionFalse, "KubeMonNotFound", "The winner or loser no longer exists")
+	}
+
+	if winner.Owner() != "" {
+		processed, err := r.payOut(ctx, fight, winner, loser)
+		if err != nil {
+			log.Error(err, "Could not pay out Fight", "Trainer", winner.Owner())
 			return ctrl.Result{}, err
 		}
+		if !processed {
+			log.Info("Waiting for payout of Fight", "Trainer", winner.Owner())
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+	}
 
-		if err := r.Delete(ctx, &fight); err != nil {
-			log.Error(err, "Could not delete Fight")
+	if !fight.Status.Rewards.Winner {
+		fight.Status.Rewards.Winner = true
+		if err := r.Status().Update(ctx, fight); err != nil {
+			log.Error(err, "Could not update status of Fight")
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, nil
+		xp := loser.ExperienceYield(winner)
+		var levels int32
+		err := r.updateKubeMon(ctx, kubeMonKey(fight.Status.Winner), func(mon *kubemon.KubeMon) error {
+			var err error
+			levels, err = mon.RecordWin(xp)
+			winner = mon
+			return err
+		})
+		if client.IgnoreNotFound(err) != nil && err != kubemon.ErrSpeciesNotFound {
+			log.Error(err, "Could not award experience to KubeMon", "KubeMon", winner.Name())
+			return ctrl.Result{}, err
+		}
+		if levels > 0 {
+			metrics.LevelUps.WithLabelValues(winner.Species().Name).Add(float64(levels))
+			recordEvent(r.Recorder, fight, []*kubemon.KubeMon{winner}, corev1.EventTypeNormal, EventReasonLevelUp, fmt.Sprintf(FightMessageLevelUp, winner.Name(), winner.Level()))
+		}
 	}
 
-	if fight.Status.NextMon == 1 {
-		if err := mon1.Attack(mon2); err != nil {
-			log.Error(err, "Could not execute attack", "Attacker", mon1.Name(), "Defender", mon2.Name())
+	if !fight.Status.Rewards.Loser {
+		fight.Status.Rewards.Loser = true
+		if err := r.Status().Update(ctx, fight); err != nil {
+			log.Error(err, "Could not update status of Fight")
 			return ctrl.Result{}, err
 		}
-	} else {
-		if err := mon2.Attack(mon1); err != nil {
-			log.Error(err, "Could not execute attack", "Attacker", mon2.Name(), "Defender", mon1.Name())
+
+		err := r.updateKubeMon(ctx, kubeMonKey(fight.Status.Loser), func(mon *kubemon.KubeMon) error {
+			return mon.RecordFightResult(false)
+		})
+		if client.IgnoreNotFound(err) != nil && err != kubemon.ErrSpeciesNotFound {
+			log.Error(err, "Could not record loss of KubeMon", "KubeMon", loser.Name())
 			return ctrl.Result{}, err
 		}
 	}
 
-	fight.Status.TurnNumber += 1
-	if fight.Status.NextMon == 1 {
-		fight.Status.NextMon = 2
-	} else {
-		fight.Status.NextMon = 1
+	return ctrl.Result{}, r.setRewarded(ctx, fight, metav1.ConditionTrue, "Rewarded", "The winner has been rewarded")
+}
+
+// abortFight ends the Fight without a winner
+func (r *FightReconciler) abortFight(ctx context.Context, fight *kubemonv1.Fight, message string, mons ...*kubemon.KubeMon) error {
+	log := log.FromContext(ctx)
+
+	if err := r.deleteFightActions(ctx, fight, nil); err != nil {
+		log.Error(err, "Could not delete FightActions of Fight")
+		return err
 	}
-	if err := r.Status().Update(ctx, &fight); err != nil {
+
+	fight.Status.Phase = kubemonv1.FightAborted
+	fight.Status.FinishedAt = ptr.To(metav1.Now())
+	if err := r.appendFightLog(ctx, fight, kubemonv1.FightLogEntry{Turn: fight.Status.TurnNumber, Message: message}); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return err
+	}
+	if err := r.Status().Update(ctx, fight); err != nil {
 		log.Error(err, "Could not update status of Fight")
+		return err
+	}
+	log.Info("Fight aborted", "Reason", message)
+	observeFightEnded(fight)
+	recordEvent(r.Recorder, fight, mons, corev1.EventTypeWarning, EventReasonFightAborted, message)
+	return nil
+}
 
+// observeFightEnded records the metrics of a Fight which finished or was aborted
+func observeFightEnded(fight *kubemonv1.Fight) {
+	metrics.FightsEnded.WithLabelValues(string(fight.Spec.Mode), string(fight.Status.Phase)).Inc()
+	metrics.FightTurns.Observe(float64(fight.Status.TurnNumber))
+}
+
+// cleanupFight deletes an ended Fight once its TTL expired
+func (r *FightReconciler) cleanupFight(ctx context.Context, fight *kubemonv1.Fight) (ctrl.Result, error) {
+	ttl := fight.Spec.TTLSecondsAfterFinished
+	if ttl == nil {
+		ttl = r.DefaultTTLSecondsAfterFinished
+	}
+	if ttl == nil || fight.Status.FinishedAt == nil {
+		return ctrl.Result{}, nil
+	}
+
+	remaining := time.Until(fight.Status.FinishedAt.Add(time.Duration(*ttl) * time.Second))
+	if remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	log.FromContext(ctx).Info("Deleting Fight, as its TTL expired")
+	if err := r.Delete(ctx, fight, client.Preconditions{UID: &fight.UID}); client.IgnoreNotFound(err) != nil {
 		return ctrl.Result{}, err
 	}
+	return ctrl.Result{}, nil
+}
 
-	log.Info("Got through reconcile! requeuing")
-	return ctrl.Result{Requeue: true}, nil
+// getParticipants returns the KubeMons currently fighting.
+// If one of them is not ready to fight, the reason and a message explaining it are returned instead.
+func (r *FightReconciler) getParticipants(ctx context.Context, fight *kubemonv1.Fight) ([2]*kubemon.KubeMon, string, string, error) {
+	var mons [2]*kubemon.KubeMon
+	for i := range mons {
+		name := activeKubeMon(fight, int32(i+1))
+
+		granted, err := fightGranted(ctx, r.Client, fight, name)
+		if err != nil {
+			return mons, "", "", err
+		}
+		if !granted {
+			return mons, "NotGranted", fmt.Sprintf(FightMessageNotGranted, name), nil
+		}
+
+		mons[i], err = r.getKubeMon(ctx, name)
+		if err == kubemon.ErrSpeciesNotFound {
+			return mons, "SpeciesNotFound", fmt.Sprintf(FightMessageMonSpeciesNotFound, name), nil
+		}
+		if apierrors.IsNotFound(err) {
+			return mons, "KubeMonNotFound", fmt.Sprintf(FightMessageMonNotFound, name), nil
+		}
+		if err != nil {
+			return mons, "", "", err
+		}
+	}
+
+	message, err := r.claimKubeMons(ctx, fight, mons[:])
+	if err != nil || message != "" {
+		return mons, "KubeMonInBattle", message, err
+	}
+	return mons, "", "", nil
+}
+
+// claimKubeMons claims the KubeMons for the Fight, so they cannot take part in another Fight at the same time.
+// Claims of Fights which ended or no longer exist are taken over. If a KubeMon is still fighting in another Fight,
+// a message explaining it is returned and none of the KubeMons are claimed.
+func (r *FightReconciler) claimKubeMons(ctx context.Context, fight *kubemonv1.Fight, mons []*kubemon.KubeMon) (string, error) {
+	for _, mon := range mons {
+		busy, err := r.inOtherFight(ctx, fight, mon)
+		if err != nil {
+			return "", err
+		}
+		if busy {
+			return fmt.Sprintf(FightMessageMonInBattle, mon.Name(), mon.CurrentFight()), nil
+		}
+	}
+
+	if err := r.recordClaims(ctx, fight, mons...); err != nil {
+		return "", err
+	}
+	for _, mon := range mons {
+		if err := mon.JoinFight(fightReference(fight)); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// recordClaims records the KubeMons in the status of the Fight before they are claimed,
+// so the Fight releases every KubeMon it claimed once it ends
+func (r *FightReconciler) recordClaims(ctx context.Context, fight *kubemonv1.Fight, mons ...*kubemon.KubeMon) error {
+	changed := false
+	for _, mon := range mons {
+		ref := kubemonv1.KubeMonReference{Name: mon.Name(), Namespace: mon.Namespace()}
+		if !slices.Contains(fight.Status.ClaimedKubeMons, ref) {
+			fight.Status.ClaimedKubeMons = append(fight.Status.ClaimedKubeMons, ref)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return r.Status().Update(ctx, fight)
+}
+
+// inOtherFight reports whether the KubeMon is claimed by another Fight, which has not ended yet
+func (r *FightReconciler) inOtherFight(ctx context.Context, fight *kubemonv1.Fight, mon *kubemon.KubeMon) (bool, error) {
+	current := mon.CurrentFight()
+	if current == nil || *current == fightReference(fight) {
+		return false, nil
+	}
+
+	var other kubemonv1.Fight
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: current.Namespace, Name: current.Name}, &other); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return other.IsActive(), nil
+}
+
+// releaseKubeMons releases the claims of the Fight on its KubeMons, so they can take part in other Fights
+func (r *FightReconciler) releaseKubeMons(ctx context.Context, fight *kubemonv1.Fight) error {
+	for _, name := range fight.KubeMons() {
+		mon, err := r.getKubeMon(ctx, name)
+		if apierrors.IsNotFound(err) || err == kubemon.ErrSpeciesNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := mon.LeaveFight(fightReference(fight)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fightRand returns the random number generator for the current turn of the Fight
+func fightRand(fight *kubemonv1.Fight) *rand.Rand {
+	return kubemon.BattleRand(ptr.Deref(fight.Status.Seed, 0), fight.Status.TurnNumber)
+}
+
+// fightReference returns the reference KubeMons fighting in the Fight are claimed with
+func fightReference(fight *kubemonv1.Fight) kubemonv1.FightReference {
+	return kubemonv1.FightReference{Namespace: fight.Namespace, Name: fight.Name}
+}
+
+// waitForParticipants keeps the Fight pending, as one of its KubeMons is not ready.
+// KubeMons already claimed by the Fight are released, so pending Fights do not block each other.
+// The Event is only emitted on the Fight and the ready KubeMons once the reason changes.
+func (r *FightReconciler) waitForParticipants(ctx context.Context, fight *kubemonv1.Fight, reason, message string, mons []*kubemon.KubeMon) error {
+	if err := r.releaseKubeMons(ctx, fight); err != nil {
+		return err
+	}
+	fight.Status.Phase = kubemonv1.FightPending
+	fight.Status.LastMessage = message
+	changed := meta.SetStatusCondition(&fight.Status.Conditions, metav1.Condition{
+		Type:               kubemonv1.FightConditionParticipantsReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: fight.Generation,
+	})
+	if err := r.Status().Update(ctx, fight); err != nil {
+		return err
+	}
+	if changed {
+		recordEvent(r.Recorder, fight, mons, corev1.EventTypeWarning, EventReasonParticipantsNotReady, message)
+	}
+	return nil
+}
+
+func (r *FightReconciler) setRewarded(ctx context.Context, fight *kubemonv1.Fight, status metav1.ConditionStatus, reason, message string) error {
+	meta.SetStatusCondition(&fight.Status.Conditions, metav1.Condition{
+		Type:               kubemonv1.FightConditionRewarded,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: fight.Generation,
+	})
+	return r.Status().Update(ctx, fight)
+}
+
+// payOut creates the Transaction paying the reward of the Fight to the Trainer of the winner, in the namespace of the Trainer.
+// It reports whether the Transaction has been processed.
+func (r *FightReconciler) payOut(ctx context.Context, fight *kubemonv1.Fight, winner, loser *kubemon.KubeMon) (bool, error) {
+	// The payout is named after the UID of the Fight, so a Fight recreated under the same name is paid out again
+	name := "payout-" + string(fight.UID)
+	transaction := &kubemonv1.Transaction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: winner.Namespace(),
+			Name:      name,
+		},
+	}
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(transaction), transaction)
+	if apierrors.IsNotFound(err) {
+		transaction.Spec = kubemonv1.TransactionSpec{
+			To:             winner.Owner(),
+			Amount:         kubemon.FightPayout(loser.Level()),
+			Fight:          fight.Name,
+			FightNamespace: fight.Namespace,
+			FightUID:       fight.UID,
+			Reason:         fmt.Sprintf("%s won Fight %s against %s", winner.Name(), fight.Name, loser.Name()),
+		}
+		if err := r.Create(ctx, transaction); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, err
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return transaction.Status.Phase == kubemonv1.TransactionCompleted || transaction.Status.Phase == kubemonv1.TransactionFailed, nil
+}
+
+// activeKubeMon returns the KubeMon currently fighting for the given side
+func activeKubeMon(fight *kubemonv1.Fight, side int32) types.NamespacedName {
+	name := types.NamespacedName{Namespace: kubeMonNamespace(fight, side)}
+	if side == 1 {
+		name.Name = fight.Spec.KubeMon1.Name
+		if fight.Status.ActiveKubeMon1 != "" {
+			name.Name = fight.Status.ActiveKubeMon1
+		}
+		return name
+	}
+
+	name.Name = fight.Spec.KubeMon2.Name
+	if fight.Status.ActiveKubeMon2 != "" {
+		name.Name = fight.Status.ActiveKubeMon2
+	}
+	return name
+}
+
+// kubeMonNamespace returns the namespace of the KubeMons fighting for the given side
+func kubeMonNamespace(fight *kubemonv1.Fight, side int32) string {
+	return fight.KubeMonKey(side).Namespace
+}
+
+// kubeMonKey returns the name of a KubeMon recorded in the status of a Fight
+func kubeMonKey(ref *kubemonv1.KubeMonReference) types.NamespacedName {
+	return types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+}
+
+// fightKey returns the key Fights are indexed by in other objects
+func fightKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
+
+// updateKubeMon applies a change to the latest version of a KubeMon, read without the cache, and retries it on conflicts
+func (r *FightReconciler) updateKubeMon(ctx context.Context, name types.NamespacedName, update func(*kubemon.KubeMon) error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		apiMon := &kubemonv1.KubeMon{}
+		if err := r.APIReader.Get(ctx, name, apiMon); err != nil {
+			return err
+		}
+		mon, err := kubemon.New(ctx, r.Client, r.Status(), apiMon)
+		if err != nil {
+			return err
+		}
+		return update(mon)
+	})
 }
 
 func (r *FightReconciler) getKubeMon(ctx context.Context, name types.NamespacedName) (*kubemon.KubeMon, error) {
@@ -170,19 +670,73 @@ func (r *FightReconciler) getKubeMon(ctx context.Context, name types.NamespacedN
 	return mon, nil
 }
 
-func (r *FightReconciler) updateStatusMessage(ctx context.Context, fight *kubemonv1.Fight, message string) error {
-	fight.Status.LastMessage = message
+// fightForAction enqueues the Fight a FightAction was submitted for
+func (r *FightReconciler) fightForAction(ctx context.Context, action client.Object) []reconcile.Request {
+	spec := action.(*kubemonv1.FightAction).Spec
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: namespaceOrDefault(spec.FightNamespace, action.GetNamespace()),
+		Name:      spec.Fight,
+	}}}
+}
 
-	if err := r.Status().Update(ctx, fight); err != nil {
-		return err
+// fightForTransaction enqueues the Fight a payout was made for
+func (r *FightReconciler) fightForTransaction(ctx context.Context, transaction client.Object) []reconcile.Request {
+	spec := transaction.(*kubemonv1.Transaction).Spec
+	if spec.Fight == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: namespaceOrDefault(spec.FightNamespace, transaction.GetNamespace()),
+		Name:      spec.Fight,
+	}}}
+}
+
+// fightsForKubeMon enqueues the pending Fights of a KubeMon, so they notice it being created or released by another Fight
+func (r *FightReconciler) fightsForKubeMon(ctx context.Context, mon client.Object) []reconcile.Request {
+	var fights kubemonv1.FightList
+	if err := r.List(ctx, &fights, client.MatchingFields{fightKubeMonsField: client.ObjectKeyFromObject(mon).String()}); err != nil {
+		log.FromContext(ctx).Error(err, "Could not list Fights")
+		return nil
 	}
 
-	return nil
+	var requests []reconcile.Request
+	for _, fight := range fights.Items {
+		if !fight.HasStarted() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fight)})
+		}
+	}
+	return requests
+}
+
+// fightsForGrant enqueues all Fights involving KubeMons of the namespace of a FightGrant
+func (r *FightReconciler) fightsForGrant(ctx context.Context, grant client.Object) []reconcile.Request {
+	var fights kubemonv1.FightList
+	if err := r.List(ctx, &fights, client.MatchingFields{fightNamespacesField: grant.GetNamespace()}); err != nil {
+		log.FromContext(ctx).Error(err, "Could not list Fights")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(fights.Items))
+	for _, fight := range fights.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&fight)})
+	}
+	return requests
+}
+
+func namespaceOrDefault(namespace, def string) string {
+	if namespace == "" {
+		return def
+	}
+	return namespace
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *FightReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kubemonv1.Fight{}).
+		Watches(&kubemonv1.FightAction{}, handler.EnqueueRequestsFromMapFunc(r.fightForAction)).
+		Watches(&kubemonv1.Transaction{}, handler.EnqueueRequestsFromMapFunc(r.fightForTransaction)).
+		Watches(&kubemonv1.FightGrant{}, handler.EnqueueRequestsFromMapFunc(r.fightsForGrant)).
+		Watches(&kubemonv1.KubeMon{}, handler.EnqueueRequestsFromMapFunc(r.fightsForKubeMon)).
 		Complete(r)
 }
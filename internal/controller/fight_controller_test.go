@@ -18,18 +18,59 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
 )
 
+// newFightTestClient returns a fake client holding the objects and the Species of the KubeMons fighting in the tests.
+// Unlike the test environment, it supports the field indexes of the cache the Fight controller lists objects by.
+func newFightTestClient(objects ...client.Object) client.Client {
+	species := &kubemonv1.Species{
+		ObjectMeta: metav1.ObjectMeta{Name: "fight-species"},
+		Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithStatusSubresource(&kubemonv1.Fight{}, &kubemonv1.KubeMon{}, &kubemonv1.Inventory{}, &kubemonv1.Transaction{}).
+		WithIndex(&kubemonv1.FightAction{}, fightActionFightField, fightActionFight).
+		WithObjects(append(objects, species)...).
+		Build()
+}
+
+// receivedEvents drains the Events recorded so far
+func receivedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
 var _ = Describe("Fight Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-resource"
@@ -51,7 +92,10 @@ var _ = Describe("Fight Controller", func() {
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					// TODO(user): Specify other spec details if needed.
+					Spec: kubemonv1.FightSpec{
+						KubeMon1: kubemonv1.KubeMonReference{Name: "missing-kubemon1"},
+						KubeMon2: kubemonv1.KubeMonReference{Name: "missing-kubemon2"},
+					},
 				}
 				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
 			}
@@ -69,16 +113,476 @@ var _ = Describe("Fight Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &FightReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+				Recorder:  &record.FakeRecorder{},
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			By("Waiting for the missing KubeMons")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, fight)).To(Succeed())
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightPending))
+			Expect(meta.IsStatusConditionFalse(fight.Status.Conditions, kubemonv1.FightConditionParticipantsReady)).To(BeTrue())
+		})
+	})
+
+	Context("When fighting to the end", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "lifecycle-fight", Namespace: "default"}
+
+		newMon := func(name string, level int32) *kubemonv1.KubeMon {
+			return &kubemonv1.KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1, InitialLevel: ptr.To(level)},
+			}
+		}
+		newFight := func(mode kubemonv1.FightMode) *kubemonv1.Fight {
+			return &kubemonv1.Fight{
+				ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace, UID: "lifecycle-fight-uid"},
+				Spec: kubemonv1.FightSpec{
+					KubeMon1: kubemonv1.KubeMonReference{Name: "strong-mon"},
+					KubeMon2: kubemonv1.KubeMonReference{Name: "weak-mon"},
+					Mode:     mode,
+					Seed:     ptr.To(int64(42)),
+				},
+			}
+		}
+		reconcileFight := func(r *FightReconciler) *kubemonv1.Fight {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+			fight := &kubemonv1.Fight{}
+			Expect(r.Get(ctx, name, fight)).To(Succeed())
+			return fight
+		}
+		getMon := func(c client.Client, name string) *kubemonv1.KubeMon {
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, mon)).To(Succeed())
+			return mon
+		}
+
+		It("should go from Pending to InProgress to Finished and reward the winner", func() {
+			c := newFightTestClient(newMon("strong-mon", 30), newFight(kubemonv1.FightModeAuto))
+			recorder := record.NewFakeRecorder(100)
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: recorder}
+
+			By("waiting for the missing KubeMon")
+			fight := reconcileFight(r)
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightPending))
+			Expect(fight.Status.StartedAt).To(BeNil())
+			Expect(meta.FindStatusCondition(fight.Status.Conditions, kubemonv1.FightConditionParticipantsReady).Reason).To(Equal("KubeMonNotFound"))
+			Expect(receivedEvents(recorder)).To(ConsistOf(
+				"Warning ParticipantsNotReady "+fmt.Sprintf(FightMessageMonNotFound, "default/weak-mon"),
+				"Warning ParticipantsNotReady "+fmt.Sprintf(FightMessageMonNotFound, "default/weak-mon"),
+			))
+
+			By("starting once both KubeMons exist")
+			started, ended := testutil.ToFloat64(metrics.FightsStarted.WithLabelValues("Auto")), testutil.ToFloat64(metrics.FightsEnded.WithLabelValues("Auto", "Finished"))
+			Expect(c.Create(ctx, newMon("weak-mon", 2))).To(Succeed())
+			fight = reconcileFight(r)
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightInProgress))
+			Expect(fight.Status.StartedAt).NotTo(BeNil())
+			Expect(fight.Status.Seed).To(Equal(ptr.To(int64(42))))
+			Expect(meta.IsStatusConditionTrue(fight.Status.Conditions, kubemonv1.FightConditionParticipantsReady)).To(BeTrue())
+			Expect(getMon(c, "strong-mon").Status.CurrentFight).To(Equal(&kubemonv1.FightReference{Namespace: "default", Name: name.Name}))
+			Expect(testutil.ToFloat64(metrics.FightsStarted.WithLabelValues("Auto"))).To(Equal(started + 1))
+			// The first turn is fought right after starting, so the events of its attacks follow
+			startedEvent := "Normal FightStarted " + fmt.Sprintf(FightMessageStarted, "strong-mon", "weak-mon")
+			events := receivedEvents(recorder)
+			Expect(len(events)).To(BeNumerically(">", 3))
+			Expect(events[:3]).To(Equal([]string{startedEvent, startedEvent, startedEvent}))
+
+			By("fighting until the weak KubeMon faints")
+			for i := 0; i < 100 && fight.Status.Phase == kubemonv1.FightInProgress; i++ {
+				fight = reconcileFight(r)
+			}
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightFinished))
+			Expect(fight.Status.Winner).To(Equal(&kubemonv1.KubeMonReference{Name: "strong-mon", Namespace: "default"}))
+			Expect(fight.Status.Loser).To(Equal(&kubemonv1.KubeMonReference{Name: "weak-mon", Namespace: "default"}))
+			Expect(fight.Status.FinishedAt).NotTo(BeNil())
+			Expect(fight.Status.TurnNumber).To(BeNumerically(">", 0))
+			Expect(meta.IsStatusConditionTrue(fight.Status.Conditions, kubemonv1.FightConditionRewarded)).To(BeTrue())
+			Expect(fight.Status.Rewards).To(Equal(kubemonv1.FightRewards{Winner: true, Loser: true}))
+			Expect(testutil.ToFloat64(metrics.FightsEnded.WithLabelValues("Auto", "Finished"))).To(Equal(ended + 1))
+
+			By("recording the attacks, the fainting and the outcome on the Fight and both KubeMons")
+			events = append(events, receivedEvents(recorder)...)
+			Expect(events).To(ContainElement(HavePrefix("Normal Attack strong-mon used")))
+			fainted := "Normal Fainted " + fmt.Sprintf(FightMessageFainted, "weak-mon")
+			finished := "Normal FightFinished " + fmt.Sprintf(FightMessageFinished, "strong-mon", "weak-mon")
+			Expect(events).To(ContainElements(fainted, finished))
+			Expect(slices.DeleteFunc(events, func(event string) bool { return event != fainted })).To(HaveLen(2))
+
+			By("recording the outcome on the KubeMons and releasing them")
+			reconcileFight(r)
+			strong, weak := getMon(c, "strong-mon"), getMon(c, "weak-mon")
+			Expect(strong.Status.Wins).To(Equal(int32(1)))
+			Expect(weak.Status.Losses).To(Equal(int32(1)))
+			Expect(*weak.Status.HP).To(BeZero())
+			Expect(strong.Status.CurrentFight).To(BeNil())
+			Expect(weak.Status.CurrentFight).To(BeNil())
+		})
+
+		It("should let the opponent of a forfeiting KubeMon win", func() {
+			c := newFightTestClient(newMon("strong-mon", 30), newMon("weak-mon", 2), newFight(kubemonv1.FightModeInteractive))
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: record.NewFakeRecorder(100)}
+
+			By("starting the first turn")
+			fight := reconcileFight(r)
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightInProgress))
+			Expect(fight.Status.TurnStartedAt).NotTo(BeNil())
+
+			By("forfeiting with the strong KubeMon, while the weak one attacks")
+			Expect(c.Create(ctx, &kubemonv1.FightAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "give-up", Namespace: "default"},
+				Spec:       kubemonv1.FightActionSpec{Fight: name.Name, Side: 1, Turn: 0, Type: kubemonv1.FightActionForfeit},
+			})).To(Succeed())
+			Expect(c.Create(ctx, &kubemonv1.FightAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "attack", Namespace: "default"},
+				Spec:       kubemonv1.FightActionSpec{Fight: name.Name, Side: 2, Turn: 0, Type: kubemonv1.FightActionMove, Move: kubemon.StruggleMoveName},
+			})).To(Succeed())
+			fight = reconcileFight(r)
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightFinished))
+			Expect(fight.Status.Winner.Name).To(Equal("weak-mon"))
+			Expect(fight.Status.Loser.Name).To(Equal("strong-mon"))
+			Expect(meta.IsStatusConditionTrue(fight.Status.Conditions, kubemonv1.FightConditionRewarded)).To(BeTrue())
+			Expect(getMon(c, "weak-mon").Status.Wins).To(Equal(int32(1)))
+
+			actions := &kubemonv1.FightActionList{}
+			Expect(c.List(ctx, actions)).To(Succeed())
+			Expect(actions.Items).To(BeEmpty())
+		})
+	})
+
+	Context("When fighting KubeMons of another namespace", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "visiting-fight", Namespace: "default"}
+
+		newMon := func(name, namespace string) *kubemonv1.KubeMon {
+			return &kubemonv1.KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1},
+			}
+		}
+		newGrant := func(kubemons ...string) *kubemonv1.FightGrant {
+			return &kubemonv1.FightGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "visitors", Namespace: "team-b"},
+				Spec:       kubemonv1.FightGrantSpec{From: []kubemonv1.FightGrantFrom{{Namespace: "default"}}, KubeMons: kubemons},
+			}
+		}
+		// reconcileFight fights the KubeMon home-mon against the KubeMon guest-mon of the namespace team-b
+		reconcileFight := func(objects ...client.Object) *kubemonv1.Fight {
+			objects = append(objects,
+				newMon("home-mon", "default"),
+				newMon("guest-mon", "team-b"),
+				&kubemonv1.Fight{
+					ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+					Spec: kubemonv1.FightSpec{
+						KubeMon1: kubemonv1.KubeMonReference{Name: "home-mon"},
+						KubeMon2: kubemonv1.KubeMonReference{Name: "guest-mon", Namespace: "team-b"},
+					},
+				},
+			)
+			c := newFightTestClient(objects...)
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: record.NewFakeRecorder(10)}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+			fight := &kubemonv1.Fight{}
+			Expect(c.Get(ctx, name, fight)).To(Succeed())
+			return fight
+		}
+		expectNotGranted := func(fight *kubemonv1.Fight) {
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightPending))
+			condition := meta.FindStatusCondition(fight.Status.Conditions, kubemonv1.FightConditionParticipantsReady)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("NotGranted"))
+		}
+
+		It("should start the Fight if a FightGrant allows it", func() {
+			fight := reconcileFight(newGrant())
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightInProgress))
+			Expect(meta.IsStatusConditionTrue(fight.Status.Conditions, kubemonv1.FightConditionParticipantsReady)).To(BeTrue())
+		})
+
+		It("should keep the Fight pending without a FightGrant", func() {
+			expectNotGranted(reconcileFight())
+		})
+
+		It("should keep the Fight pending if the FightGrant is for another namespace", func() {
+			grant := newGrant()
+			grant.Spec.From[0].Namespace = "team-c"
+			expectNotGranted(reconcileFight(grant))
+		})
+
+		It("should only start the Fight if the KubeMon is on the list of the FightGrant", func() {
+			expectNotGranted(reconcileFight(newGrant("other-mon")))
+
+			fight := reconcileFight(newGrant("other-mon", "guest-mon"))
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightInProgress))
+		})
+	})
+
+	Context("When both KubeMons fainted", func() {
+		ctx := context.Background()
+
+		It("should end the Fight in a draw", func() {
+			c := newFightTestClient(
+				&kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{Name: "fainted-mon1", Namespace: "default"},
+					Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1},
+					Status:     kubemonv1.KubeMonStatus{HP: ptr.To(int32(0))},
+				},
+				&kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{Name: "fainted-mon2", Namespace: "default"},
+					Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1},
+					Status:     kubemonv1.KubeMonStatus{HP: ptr.To(int32(0))},
+				},
+				&kubemonv1.Fight{
+					ObjectMeta: metav1.ObjectMeta{Name: "draw-fight", Namespace: "default"},
+					Spec: kubemonv1.FightSpec{
+						KubeMon1: kubemonv1.KubeMonReference{Name: "fainted-mon1"},
+						KubeMon2: kubemonv1.KubeMonReference{Name: "fainted-mon2"},
+					},
+				},
+			)
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: record.NewFakeRecorder(10)}
+			name := types.NamespacedName{Name: "draw-fight", Namespace: "default"}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			fight := &kubemonv1.Fight{}
+			Expect(c.Get(ctx, name, fight)).To(Succeed())
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightFinished))
+			Expect(fight.Status.Draw).To(BeTrue())
+			Expect(fight.Status.Winner).To(BeNil())
+			Expect(fight.Status.Loser).To(BeNil())
+			Expect(fight.Status.FinishedAt).NotTo(BeNil())
+			Expect(fight.Status.LastMessage).To(Equal(fmt.Sprintf(FightMessageBothFainted, "fainted-mon1", "fainted-mon2")))
+			condition := meta.FindStatusCondition(fight.Status.Conditions, kubemonv1.FightConditionRewarded)
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Draw"))
+
+			By("neither rewarding nor counting the draw, and releasing the KubeMons")
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.Get(ctx, name, fight)).To(Succeed())
+			Expect(fight.Status.Rewards).To(Equal(kubemonv1.FightRewards{}))
+			for _, monName := range []string{"fainted-mon1", "fainted-mon2"} {
+				mon := &kubemonv1.KubeMon{}
+				Expect(c.Get(ctx, types.NamespacedName{Name: monName, Namespace: "default"}, mon)).To(Succeed())
+				Expect(mon.Status.Wins).To(BeZero())
+				Expect(mon.Status.Losses).To(BeZero())
+				Expect(mon.Status.CurrentFight).To(BeNil())
+			}
+		})
+	})
+
+	Context("When a fighting KubeMon disappears", func() {
+		ctx := context.Background()
+
+		It("should abort the Fight", func() {
+			c := newFightTestClient(
+				&kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{Name: "staying-mon", Namespace: "default"},
+					Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1},
+				},
+				&kubemonv1.Fight{
+					ObjectMeta: metav1.ObjectMeta{Name: "abandoned-fight", Namespace: "default"},
+					Spec: kubemonv1.FightSpec{
+						KubeMon1: kubemonv1.KubeMonReference{Name: "staying-mon"},
+						KubeMon2: kubemonv1.KubeMonReference{Name: "vanished-mon"},
+					},
+					Status: kubemonv1.FightStatus{Phase: kubemonv1.FightInProgress},
+				},
+			)
+			recorder := record.NewFakeRecorder(10)
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: recorder}
+			name := types.NamespacedName{Name: "abandoned-fight", Namespace: "default"}
+
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			fight := &kubemonv1.Fight{}
+			Expect(c.Get(ctx, name, fight)).To(Succeed())
+			Expect(fight.Status.Phase).To(Equal(kubemonv1.FightAborted))
+			Expect(fight.Status.LastMessage).To(Equal(fmt.Sprintf(FightMessageMonNotFound, "default/vanished-mon")))
+			By("recording the event only on the Fight and the remaining KubeMon")
+			Expect(recorder.Events).To(HaveLen(2))
+		})
+	})
+
+	Context("When a sleeping KubeMon does not attack", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "sleepy-fight", Namespace: "default"}
+
+		It("should still count down its sleep", func() {
+			c := newFightTestClient(
+				&kubemonv1.Item{
+					ObjectMeta: metav1.ObjectMeta{Name: "potion"},
+					Spec:       kubemonv1.ItemSpec{Category: kubemonv1.ItemCategoryPotion, HealAmount: 20},
+				},
+				&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"}},
+				&kubemonv1.Inventory{
+					ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"},
+					Status:     kubemonv1.InventoryStatus{Items: []kubemonv1.ItemStack{{Name: "potion", Quantity: 1}}},
+				},
+				&kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{Name: "sleepy-mon", Namespace: "default"},
+					Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Owner: "tobi", Strength: 1, InitialLevel: ptr.To(int32(20))},
+					Status: kubemonv1.KubeMonStatus{
+						HP:      ptr.To(int32(5)),
+						Ailment: &kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentSleep, SleepTurns: 2},
+					},
+				},
+				&kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{Name: "awake-mon", Namespace: "default"},
+					Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1, InitialLevel: ptr.To(int32(20))},
+				},
+				&kubemonv1.Fight{
+					ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace, UID: "sleepy-fight-uid"},
+					Spec: kubemonv1.FightSpec{
+						KubeMon1: kubemonv1.KubeMonReference{Name: "sleepy-mon"},
+						KubeMon2: kubemonv1.KubeMonReference{Name: "awake-mon"},
+						Mode:     kubemonv1.FightModeInteractive,
+						Seed:     ptr.To(int64(42)),
+					},
+				},
+			)
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c, Recorder: record.NewFakeRecorder(100)}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("using a Potion on the sleeping KubeMon instead of attacking")
+			Expect(c.Create(ctx, &kubemonv1.FightAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "heal-up", Namespace: "default"},
+				Spec:       kubemonv1.FightActionSpec{Fight: name.Name, Side: 1, Turn: 0, Type: kubemonv1.FightActionItem, Item: "potion"},
+			})).To(Succeed())
+			Expect(c.Create(ctx, &kubemonv1.FightAction{
+				ObjectMeta: metav1.ObjectMeta{Name: "attack", Namespace: "default"},
+				Spec:       kubemonv1.FightActionSpec{Fight: name.Name, Side: 2, Turn: 0, Type: kubemonv1.FightActionMove, Move: kubemon.StruggleMoveName},
+			})).To(Succeed())
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			fight := &kubemonv1.Fight{}
+			Expect(c.Get(ctx, name, fight)).To(Succeed())
+			Expect(fight.Status.LastTurn).NotTo(BeNil())
+			sleepy := fight.Status.LastTurn.KubeMons[0]
+			Expect(sleepy.Name).To(Equal("sleepy-mon"))
+			Expect(sleepy.Items).To(Equal([]string{"potion"}))
+			Expect(sleepy.Ailment).To(Equal(&kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentSleep, SleepTurns: 1}))
+		})
+	})
+
+	Context("When ordering the attackers of a turn", func() {
+		ctx := context.Background()
+
+		// newCombatant returns a combatant for side 1 or 2, the speed of its KubeMon grows with its level
+		newCombatant := func(c client.Client, side int32, level int32, ailment kubemonv1.Ailment, priority int32) *combatant {
+			apiMon := &kubemonv1.KubeMon{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("side-%d", side), Namespace: "default"},
+				Spec:       kubemonv1.KubeMonSpec{Species: "fight-species", Strength: 1, InitialLevel: ptr.To(level)},
+			}
+			if ailment != "" {
+				apiMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: ailment}
+			}
+			Expect(c.Create(ctx, apiMon)).To(Succeed())
+			mon, err := kubemon.New(ctx, c, c.Status(), apiMon)
+			Expect(err).NotTo(HaveOccurred())
+			return &combatant{side: side, mon: mon, move: kubemon.Move{Name: "tackle", Spec: kubemonv1.MoveSpec{Priority: priority}}}
+		}
+
+		It("should let the Move with the higher priority go first, then the faster KubeMon, then the higher tie-break", func() {
+			cases := []struct {
+				name                 string
+				level1, level2       int32
+				ailment1             kubemonv1.Ailment
+				priority1, priority2 int32
+				tieBreak1, tieBreak2 int64
+				side1First           bool
+			}{
+				{name: "higher priority of the slower KubeMon", level1: 10, level2: 50, priority1: 1, side1First: true},
+				{name: "lower priority of the faster KubeMon", level1: 50, level2: 10, priority2: 1, side1First: false},
+				{name: "faster KubeMon", level1: 50, level2: 10, side1First: true},
+				{name: "slower KubeMon", level1: 10, level2: 50, side1First: false},
+				{name: "faster KubeMon slowed down by paralysis", level1: 50, level2: 40, ailment1: kubemonv1.AilmentParalysis, side1First: false},
+				{name: "higher tie-break", level1: 30, level2: 30, tieBreak1: 2, tieBreak2: 1, side1First: true},
+				{name: "lower tie-break", level1: 30, level2: 30, tieBreak1: 1, tieBreak2: 2, side1First: false},
+			}
+			for _, tc := range cases {
+				By(tc.name)
+				c := newFightTestClient()
+				a := newCombatant(c, 1, tc.level1, tc.ailment1, tc.priority1)
+				b := newCombatant(c, 2, tc.level2, "", tc.priority2)
+				a.tieBreak, b.tieBreak = tc.tieBreak1, tc.tieBreak2
+				Expect(attacksFirst(a, b)).To(Equal(tc.side1First), tc.name)
+				Expect(attacksFirst(b, a)).To(Equal(!tc.side1First), tc.name)
+			}
+		})
+
+		It("should break ties the same way for the same seed", func() {
+			c := newFightTestClient()
+			a := newCombatant(c, 1, 30, "", 0)
+			b := newCombatant(c, 2, 30, "", 0)
+
+			firstSides := map[int32]bool{}
+			for seed := int64(0); seed < 20; seed++ {
+				attackers := []*combatant{a, b}
+				orderAttackers(attackers, kubemon.BattleRand(seed, 1))
+				replayed := []*combatant{a, b}
+				orderAttackers(replayed, kubemon.BattleRand(seed, 1))
+				Expect(replayed[0].side).To(Equal(attackers[0].side))
+				firstSides[attackers[0].side] = true
+			}
+			Expect(firstSides).To(HaveLen(2))
+		})
+	})
+
+	Context("When archiving the log", func() {
+		ctx := context.Background()
+
+		It("should archive every entry only once", func() {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			fight := &kubemonv1.Fight{ObjectMeta: metav1.ObjectMeta{Name: "log-fight", Namespace: "default", UID: "log-fight-uid"}}
+			entries := []kubemonv1.FightLogEntry{{Turn: 0, Message: "a"}, {Turn: 0, Message: "b"}, {Turn: 1, Message: "c"}}
+
+			By("archiving the same entries twice, as after a failed status update")
+			Expect(r.archiveFightLog(ctx, fight, 0, entries)).To(Succeed())
+			Expect(r.archiveFightLog(ctx, fight, 0, entries)).To(Succeed())
+
+			By("archiving entries overlapping the archived ones")
+			Expect(r.archiveFightLog(ctx, fight, 2, []kubemonv1.FightLogEntry{{Turn: 1, Message: "c"}, {Turn: 2, Message: "d"}})).To(Succeed())
+
+			configMap := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: fight.Status.LogConfigMap}, configMap)).To(Succeed())
+			lines := strings.Split(strings.TrimSuffix(configMap.Data[fightLogKey], "\n"), "\n")
+			Expect(lines).To(HaveLen(4))
+			Expect(lines[3]).To(ContainSubstring(`"message":"d"`))
+			Expect(configMap.Annotations).To(HaveKeyWithValue(FightLogArchivedAnnotation, "4"))
+		})
+
+		It("should drop the oldest entries once the log grows too large", func() {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			r := &FightReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			fight := &kubemonv1.Fight{ObjectMeta: metav1.ObjectMeta{Name: "long-fight", Namespace: "default", UID: "long-fight-uid"}}
+			message := strings.Repeat("x", 8*1024)
+
+			for i := int32(0); i < 120; i++ {
+				Expect(r.archiveFightLog(ctx, fight, i, []kubemonv1.FightLogEntry{{Turn: i, Message: message}})).To(Succeed())
+			}
+
+			configMap := &corev1.ConfigMap{}
+			Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: fight.Status.LogConfigMap}, configMap)).To(Succeed())
+			Expect(len(configMap.Data[fightLogKey])).To(BeNumerically("<=", fightLogMaxBytes))
+			Expect(configMap.Annotations).To(HaveKeyWithValue(FightLogArchivedAnnotation, "120"))
+			Expect(configMap.Annotations).To(HaveKey(FightLogDroppedAnnotation))
 		})
 	})
 })
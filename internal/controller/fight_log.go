@@ -0,0 +1,185 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+const (
+	// FightLabel names the Fight an object belongs to
+	FightLabel = "kubemon.memetoasty.github.com/fight"
+	// fightLogKey is the key of the log in the log ConfigMap of a Fight, holding one JSON encoded entry per line
+	fightLogKey = "log"
+	// FightLogArchivedAnnotation counts the entries ever written to the log ConfigMap of a Fight.
+	// Entries are numbered by their position in the whole log, so entries already written are skipped when archiving them again.
+	FightLogArchivedAnnotation = "kubemon.memetoasty.github.com/archived-entries"
+	// FightLogDroppedAnnotation counts the oldest entries dropped from the log ConfigMap of a Fight to keep it below fightLogMaxBytes
+	FightLogDroppedAnnotation = "kubemon.memetoasty.github.com/dropped-entries"
+	// fightLogMaxBytes is the size the log in a ConfigMap is limited to, leaving room below the 1 MiB limit of ConfigMaps
+	fightLogMaxBytes = 768 * 1024
+)
+
+// attackLogEntry returns the log entry for a KubeMon using a Move
+func attackLogEntry(turn int32, result *kubemon.AttackResult) kubemonv1.FightLogEntry {
+	return kubemonv1.FightLogEntry{
+		Turn:          turn,
+		Actor:         result.Attacker,
+		Move:          result.Move,
+		Target:        result.Defender,
+		Damage:        result.Damage,
+		Healed:        result.Healed,
+		TargetHP:      ptr.To(result.DefenderHP),
+		Critical:      result.Critical,
+		Missed:        result.Missed,
+		Effectiveness: string(result.Effectiveness),
+		Ailment:       result.Ailment,
+		Message:       result.Message(),
+	}
+}
+
+// logMessage returns the messages of the given log entries joined into one
+func logMessage(entries []kubemonv1.FightLogEntry) string {
+	messages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// appendFightLog adds the entries to the log in the status of the Fight and sets its last message.
+// Once the log exceeds kubemonv1.FightLogMaxEntries, the oldest entries are rolled into the log ConfigMap of the Fight.
+// The status of the Fight still has to be updated by the caller.
+func (r *FightReconciler) appendFightLog(ctx context.Context, fight *kubemonv1.Fight, entries ...kubemonv1.FightLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	fight.Status.LastMessage = logMessage(entries)
+	fight.Status.Log = append(fight.Status.Log, entries...)
+
+	overflow := len(fight.Status.Log) - kubemonv1.FightLogMaxEntries
+	if overflow <= 0 {
+		return nil
+	}
+	if err := r.archiveFightLog(ctx, fight, fight.Status.ArchivedLogEntries, fight.Status.Log[:overflow]); err != nil {
+		return err
+	}
+	fight.Status.Log = fight.Status.Log[overflow:]
+	fight.Status.ArchivedLogEntries += int32(overflow)
+	return nil
+}
+
+// archiveFightLog appends the entries to the log ConfigMap of the Fight, which is created if it does not exist yet.
+// first is the position of the first entry in the whole log. Entries the ConfigMap already holds are skipped,
+// so retrying after the status of the Fight could not be updated does not archive them twice.
+// Once the log in the ConfigMap grows beyond fightLogMaxBytes, its oldest entries are dropped.
+// The ConfigMap is owned by the Fight, so it is deleted together with it. It is read without the cache,
+// so the manager does not need to watch all ConfigMaps of the cluster.
+func (r *FightReconciler) archiveFightLog(ctx context.Context, fight *kubemonv1.Fight, first int32, entries []kubemonv1.FightLogEntry) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: fight.Namespace,
+			Name:      fight.Name + "-log",
+		},
+	}
+	err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+	exists := err == nil
+	if apierrors.IsNotFound(err) {
+		configMap.Labels = map[string]string{FightLabel: fight.Name}
+		if err := ctrl.SetControllerReference(fight, configMap, r.Scheme); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	archived := annotationCount(configMap, FightLogArchivedAnnotation)
+	if skip := archived - first; skip > 0 {
+		entries = entries[min(int(skip), len(entries)):]
+	}
+	if exists && len(entries) == 0 {
+		fight.Status.LogConfigMap = configMap.Name
+		return nil
+	}
+
+	var lines strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		lines.Write(line)
+		lines.WriteByte('\n')
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	log := configMap.Data[fightLogKey] + lines.String()
+	dropped := annotationCount(configMap, FightLogDroppedAnnotation)
+	for len(log) > fightLogMaxBytes {
+		end := strings.IndexByte(log, '\n')
+		if end < 0 {
+			log = ""
+			break
+		}
+		log = log[end+1:]
+		dropped++
+	}
+	configMap.Data[fightLogKey] = log
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[FightLogArchivedAnnotation] = strconv.Itoa(int(max(archived, first) + int32(len(entries))))
+	if dropped > 0 {
+		configMap.Annotations[FightLogDroppedAnnotation] = strconv.Itoa(int(dropped))
+	}
+
+	if exists {
+		err = r.Update(ctx, configMap)
+	} else {
+		err = r.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	fight.Status.LogConfigMap = configMap.Name
+	return nil
+}
+
+// annotationCount returns the count stored in an annotation of the object, or 0 if it is not set
+func annotationCount(obj client.Object, annotation string) int32 {
+	count, err := strconv.ParseInt(obj.GetAnnotations()[annotation], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(count)
+}
@@ -0,0 +1,479 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
+)
+
+const (
+	defaultTurnTimeout = 60 * time.Second
+)
+
+// combatant is a side of a Fight during the resolution of a turn
+type combatant struct {
+	side   int32
+	mon    *kubemon.KubeMon
+	action *kubemonv1.FightAction
+	move   kubemon.Move
+	// tieBreak decides the order of combatants with the same priority and speed
+	tieBreak int64
+}
+
+// reconcileInteractive resolves the current turn of an interactive Fight once both sides submitted a FightAction,
+// or once the turn timed out
+func (r *FightReconciler) reconcileInteractive(ctx context.Context, fight *kubemonv1.Fight, mon1, mon2 *kubemon.KubeMon) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	timeout := turnTimeout(fight)
+	if fight.Status.TurnStartedAt == nil {
+		fight.Status.TurnStartedAt = ptr.To(metav1.Now())
+		if err := r.Status().Update(ctx, fight); err != nil {
+			log.Error(err, "Could not update status of Fight")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: timeout}, nil
+	}
+
+	actions, err := r.turnActions(ctx, fight)
+	if err != nil {
+		log.Error(err, "Could not list FightActions of Fight")
+		return ctrl.Result{}, err
+	}
+
+	remaining := time.Until(fight.Status.TurnStartedAt.Add(timeout))
+	if (actions[0] == nil || actions[1] == nil) && remaining > 0 {
+		log.Info("Waiting for FightActions", "Turn", fight.Status.TurnNumber)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	forfeit1 := actions[0] != nil && actions[0].Spec.Type == kubemonv1.FightActionForfeit
+	forfeit2 := actions[1] != nil && actions[1].Spec.Type == kubemonv1.FightActionForfeit
+	switch {
+	case forfeit1 && forfeit2:
+		return ctrl.Result{}, r.abortFight(ctx, fight, fmt.Sprintf(FightMessageBothForfeit, mon1.Name(), mon2.Name()), mon1, mon2)
+	case forfeit1:
+		return r.forfeitFight(ctx, fight, mon1, mon2)
+	case forfeit2:
+		return r.forfeitFight(ctx, fight, mon2, mon1)
+	}
+
+	combatants := []*combatant{
+		{side: 1, mon: mon1.Simulate(), action: actions[0]},
+		{side: 2, mon: mon2.Simulate(), action: actions[1]},
+	}
+
+	turn := fight.Status.TurnNumber
+	entries, err := r.resolveTurn(ctx, fight, combatants)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.appendFightLog(ctx, fight, entries...); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return ctrl.Result{}, err
+	}
+	fight.Status.TurnNumber += 1
+	fight.Status.TurnStartedAt = ptr.To(metav1.Now())
+	if err := r.Status().Update(ctx, fight); err != nil {
+		log.Error(err, "Could not update status of Fight")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.deleteFightActions(ctx, fight, ptr.To(turn)); err != nil {
+		log.Error(err, "Could not delete FightActions of Fight")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// forfeitFight finishes the Fight with the opponent of the forfeiting KubeMon as the winner, who is rewarded as usual
+func (r *FightReconciler) forfeitFight(ctx context.Context, fight *kubemonv1.Fight, forfeiting, opponent *kubemon.KubeMon) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	log.Info("KubeMon forfeited the Fight", "KubeMon", forfeiting.Name())
+	if err := r.appendFightLog(ctx, fight, kubemonv1.FightLogEntry{
+		Turn:    fight.Status.TurnNumber,
+		Actor:   forfeiting.Name(),
+		Message: fmt.Sprintf(FightMessageForfeit, forfeiting.Name()),
+	}); err != nil {
+		log.Error(err, "Could not write log of Fight")
+		return ctrl.Result{}, err
+	}
+	return r.finishFight(ctx, fight, opponent, forfeiting)
+}
+
+// resolveTurn executes the actions of both sides. Switches and Items are handled first, then Moves
+// ordered by their priority and the speed of the KubeMons. Combatants without an action use their default Move. It returns the log entries of the turn.
+// The turn is resolved on simulated KubeMons and its outcome is recorded as the last turn in the status of the Fight,
+// which is applied to the KubeMons once it has been written.
+func (r *FightReconciler) resolveTurn(ctx context.Context, fight *kubemonv1.Fight, combatants []*combatant) ([]kubemonv1.FightLogEntry, error) {
+	log := log.FromContext(ctx)
+	turn := fight.Status.TurnNumber
+	var entries []kubemonv1.FightLogEntry
+
+	rng := fightRand(fight)
+	items := map[*combatant][]string{}
+	var attackers []*combatant
+	for _, c := range combatants {
+		opponent := combatants[2-c.side].mon
+
+		if c.action != nil && c.action.Spec.Type == kubemonv1.FightActionSwitch {
+			target, err := r.switchTarget(ctx, fight, c.mon, opponent, c.action.Spec.KubeMon)
+			if err != nil {
+				return nil, err
+			}
+			if target != nil {
+				entries = append(entries, kubemonv1.FightLogEntry{
+					Turn:    turn,
+					Actor:   c.mon.Name(),
+					Target:  target.Name(),
+					Message: fmt.Sprintf(FightMessageSwitch, c.mon.Name(), target.Name()),
+				})
+				setActiveKubeMon(fight, c.side, target.Name())
+				c.mon = target
+				continue
+			}
+			entries = append(entries, invalidActionLogEntry(turn, c.mon, "switch to "+c.action.Spec.KubeMon))
+		}
+
+		if c.action != nil && c.action.Spec.Type == kubemonv1.FightActionItem {
+			err := r.useFightItem(ctx, c.mon, c.action.Spec.Item)
+			if err == nil {
+				items[c] = append(items[c], c.action.Spec.Item)
+				entries = append(entries, kubemonv1.FightLogEntry{
+					Turn:     turn,
+					Actor:    c.mon.Owner(),
+					Item:     c.action.Spec.Item,
+					Target:   c.mon.Name(),
+					TargetHP: ptr.To(c.mon.HP()),
+					Message:  fmt.Sprintf(FightMessageItem, c.mon.Owner(), c.action.Spec.Item, c.mon.Name()),
+				})
+				continue
+			}
+			if client.IgnoreNotFound(err) != nil && err != ErrItemNotInInventory && err != kubemon.ErrItemNoEffect {
+				return nil, err
+			}
+			entries = append(entries, invalidActionLogEntry(turn, c.mon, "use "+c.action.Spec.Item))
+		}
+
+		c.move = c.mon.SelectMove(opponent)
+		if c.action != nil && c.action.Spec.Type == kubemonv1.FightActionMove {
+			if move, ok := c.mon.Move(c.action.Spec.Move); ok {
+				c.move = move
+			} else {
+				entries = append(entries, invalidActionLogEntry(turn, c.mon, "use "+c.action.Spec.Move))
+			}
+		}
+		attackers = append(attackers, c)
+	}
+
+	orderAttackers(attackers, rng)
+
+	// Ailments tick for every active KubeMon, also for the ones switching or using an Item this turn, so they wake up on time
+	canMove := map[*combatant]bool{}
+	for _, c := range combatants {
+		ok, wokeUp, err := c.mon.CheckAilment(rng)
+		if err != nil {
+			return nil, err
+		}
+		if wokeUp {
+			entries = append(entries, ailmentLogEntry(turn, c.mon, FightMessageWokeUp, c.mon.Name()))
+		}
+		canMove[c] = ok
+	}
+
+	for _, c := range attackers {
+		opponent := combatants[2-c.side].mon
+		if c.mon.IsDead() || opponent.IsDead() {
+			break
+		}
+
+		if !canMove[c] {
+			message := FightMessageParalyzed
+			if c.mon.Ailment() == kubemonv1.AilmentSleep {
+				message = FightMessageAsleep
+			}
+			entries = append(entries, ailmentLogEntry(turn, c.mon, message, c.mon.Name()))
+			continue
+		}
+
+		result, err := c.mon.UseMove(c.move, opponent, rng, r.TypeChart)
+		if err != nil {
+			log.Error(err, "Could not execute attack", "Attacker", c.mon.Name(), "Defender", opponent.Name())
+			return nil, err
+		}
+		entries = append(entries, attackLogEntry(turn, result))
+		recordEvent(r.Recorder, fight, []*kubemon.KubeMon{c.mon, opponent}, corev1.EventTypeNormal, EventReasonAttack, result.Message())
+		metrics.Damage.Observe(float64(result.Damage))
+	}
+
+	// Poison and burns hurt at the end of the turn, unless a KubeMon already fainted
+	for _, c := range combatants {
+		if combatants[0].mon.IsDead() || combatants[1].mon.IsDead() {
+			break
+		}
+		ailment := c.mon.Ailment()
+		damage, err := c.mon.AilmentDamage()
+		if err != nil {
+			return nil, err
+		}
+		if damage > 0 {
+			entry := ailmentLogEntry(turn, c.mon, FightMessageAilmentDamage, c.mon.Name(), strings.ToLower(string(ailment)), damage, c.mon.HP())
+			entry.Damage, entry.Ailment = damage, ailment
+			entries = append(entries, entry)
+		}
+	}
+
+	last := &kubemonv1.FightTurnResult{Turn: turn}
+	for _, c := range combatants {
+		state := c.mon.FightState()
+		state.Items = items[c]
+		last.KubeMons = append(last.KubeMons, state)
+	}
+	fight.Status.LastTurn = last
+
+	return entries, nil
+}
+
+// useFightItem applies an Item from the Inventory of the owner to a simulated KubeMon.
+// The Item is only taken from the Inventory once the turn is applied. Evolution Items cannot be used during a Fight.
+func (r *FightReconciler) useFightItem(ctx context.Context, mon *kubemon.KubeMon, itemName string) error {
+	item, err := checkItem(ctx, r.Client, mon.Namespace(), mon, itemName)
+	if err != nil {
+		return err
+	}
+	if item.Spec.Category == kubemonv1.ItemCategoryEvolution {
+		return kubemon.ErrItemNoEffect
+	}
+	return mon.UseItem(item)
+}
+
+// applyLastTurn applies the outcome of the last turn recorded in the status of the Fight to the KubeMons, which have not seen it yet.
+// Items used during the turn are taken from the Inventories of their owners first, with a receipt for the turn, so they are only taken once.
+// It returns the participants with the turn applied.
+func (r *FightReconciler) applyLastTurn(ctx context.Context, fight *kubemonv1.Fight, mons [2]*kubemon.KubeMon) ([2]*kubemon.KubeMon, error) {
+	last := fight.Status.LastTurn
+	if last == nil {
+		return mons, nil
+	}
+
+	ref := fightReference(fight)
+	for _, state := range last.KubeMons {
+		var applied *kubemon.KubeMon
+		err := r.updateKubeMon(ctx, kubeMonKey(&state.KubeMonReference), func(mon *kubemon.KubeMon) error {
+			applied = mon
+			if !mon.AwaitsFightTurn(ref, last.Turn) {
+				return nil
+			}
+			for i, item := range state.Items {
+				err := takeItem(ctx, r.Client, mon.Namespace(), mon.Owner(), item, turnReceipt(fight, last.Turn, state.Name, i))
+				if err != nil && err != ErrItemNotInInventory {
+					return err
+				}
+			}
+			return mon.ApplyFightTurn(ref, last.Turn, state)
+		})
+		if apierrors.IsNotFound(err) || err == kubemon.ErrSpeciesNotFound {
+			continue
+		}
+		if err != nil {
+			return mons, err
+		}
+
+		// The KubeMon has seen the turn, so its Items are never taken again
+		for i := range state.Items {
+			if err := forgetReceipt(ctx, r.Client, applied.Namespace(), applied.Owner(), turnReceipt(fight, last.Turn, state.Name, i)); err != nil {
+				return mons, err
+			}
+		}
+		for i, mon := range mons {
+			if mon != nil && mon.Name() == applied.Name() && mon.Namespace() == applied.Namespace() {
+				mons[i] = applied
+			}
+		}
+	}
+	return mons, nil
+}
+
+// turnReceipt returns the receipt an Item used on a KubeMon during a turn of the Fight is taken from the Inventory with
+func turnReceipt(fight *kubemonv1.Fight, turn int32, mon string, index int) string {
+	return fmt.Sprintf("%s/%d/%s/%d", fight.UID, turn, mon, index)
+}
+
+// orderAttackers sorts the attackers of a turn by the order they move in.
+// Their tie-breaks are drawn from the random number generator of the turn, so a Fight with the same seed always plays out the same.
+func orderAttackers(attackers []*combatant, rng *rand.Rand) {
+	for _, c := range attackers {
+		c.tieBreak = rng.Int63()
+	}
+	sort.SliceStable(attackers, func(i, j int) bool {
+		return attacksFirst(attackers[i], attackers[j])
+	})
+}
+
+// attacksFirst reports whether combatant a moves before b. Moves with a higher priority go first,
+// then the faster KubeMon, and on a tie the one the random number generator of the Fight favours.
+func attacksFirst(a, b *combatant) bool {
+	if a.move.Spec.Priority != b.move.Spec.Priority {
+		return a.move.Spec.Priority > b.move.Spec.Priority
+	}
+	if a.mon.Speed() != b.mon.Speed() {
+		return a.mon.Speed() > b.mon.Speed()
+	}
+	return a.tieBreak > b.tieBreak
+}
+
+// ailmentLogEntry returns the log entry for the status ailment of a KubeMon affecting it
+func ailmentLogEntry(turn int32, mon *kubemon.KubeMon, format string, args ...any) kubemonv1.FightLogEntry {
+	return kubemonv1.FightLogEntry{
+		Turn:     turn,
+		Target:   mon.Name(),
+		TargetHP: ptr.To(mon.HP()),
+		Ailment:  mon.Ailment(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func invalidActionLogEntry(turn int32, mon *kubemon.KubeMon, action string) kubemonv1.FightLogEntry {
+	return kubemonv1.FightLogEntry{
+		Turn:    turn,
+		Actor:   mon.Name(),
+		Message: fmt.Sprintf(FightMessageInvalidAction, mon.Name(), action),
+	}
+}
+
+// switchTarget returns the KubeMon to switch in for the current one, or nil if it cannot be switched in.
+// Only healthy KubeMons of the same owner, which are not already fighting and may take part in the Fight, can be switched in.
+// The KubeMon switched in is claimed for the Fight, a simulated copy of it takes part in the turn.
+func (r *FightReconciler) switchTarget(ctx context.Context, fight *kubemonv1.Fight, current, opponent *kubemon.KubeMon, name string) (*kubemon.KubeMon, error) {
+	if name == "" || name == current.Name() || current.Owner() == "" {
+		return nil, nil
+	}
+	if name == opponent.Name() && current.Namespace() == opponent.Namespace() {
+		return nil, nil
+	}
+
+	targetName := types.NamespacedName{Namespace: current.Namespace(), Name: name}
+	granted, err := fightGranted(ctx, r.Client, fight, targetName)
+	if err != nil || !granted {
+		return nil, err
+	}
+
+	target, err := r.getKubeMon(ctx, targetName)
+	if err != nil {
+		if err == kubemon.ErrSpeciesNotFound {
+			return nil, nil
+		}
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if target.Owner() != current.Owner() || target.IsDead() {
+		return nil, nil
+	}
+
+	busy, err := r.inOtherFight(ctx, fight, target)
+	if err != nil || busy {
+		return nil, err
+	}
+	if err := r.recordClaims(ctx, fight, target); err != nil {
+		return nil, err
+	}
+	if err := target.JoinFight(fightReference(fight)); err != nil {
+		return nil, err
+	}
+	return target.Simulate(), nil
+}
+
+// turnActions returns the latest FightAction submitted by each side for the current turn.
+// FightActions for a side are only accepted from the namespace of the KubeMons of that side.
+func (r *FightReconciler) turnActions(ctx context.Context, fight *kubemonv1.Fight) ([2]*kubemonv1.FightAction, error) {
+	var actions [2]*kubemonv1.FightAction
+
+	var list kubemonv1.FightActionList
+	if err := r.List(ctx, &list, client.MatchingFields{fightActionFightField: fightKey(fight.Namespace, fight.Name)}); err != nil {
+		return actions, err
+	}
+
+	for i := range list.Items {
+		action := &list.Items[i]
+		if action.Spec.Turn != fight.Status.TurnNumber || action.Spec.Side < 1 || action.Spec.Side > 2 {
+			continue
+		}
+		if action.Namespace != kubeMonNamespace(fight, action.Spec.Side) {
+			continue
+		}
+
+		latest := actions[action.Spec.Side-1]
+		if latest == nil || latest.CreationTimestamp.Before(&action.CreationTimestamp) {
+			actions[action.Spec.Side-1] = action
+		}
+	}
+	return actions, nil
+}
+
+// deleteFightActions deletes the FightActions of a Fight up to the given turn, or all of them if turn is nil
+func (r *FightReconciler) deleteFightActions(ctx context.Context, fight *kubemonv1.Fight, turn *int32) error {
+	var list kubemonv1.FightActionList
+	if err := r.List(ctx, &list, client.MatchingFields{fightActionFightField: fightKey(fight.Namespace, fight.Name)}); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		if turn != nil && list.Items[i].Spec.Turn > *turn {
+			continue
+		}
+		if err := r.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setActiveKubeMon(fight *kubemonv1.Fight, side int32, name string) {
+	if side == 1 {
+		fight.Status.ActiveKubeMon1 = name
+	} else {
+		fight.Status.ActiveKubeMon2 = name
+	}
+}
+
+func turnTimeout(fight *kubemonv1.Fight) time.Duration {
+	if fight.Spec.TurnTimeoutSeconds == nil {
+		return defaultTurnTimeout
+	}
+	return time.Duration(*fight.Spec.TurnTimeoutSeconds) * time.Second
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+// fightGranted reports whether the KubeMon may take part in the Fight.
+// KubeMons in the namespace of the Fight may always fight, KubeMons in other namespaces need a FightGrant in their namespace.
+func fightGranted(ctx context.Context, c client.Reader, fight *kubemonv1.Fight, mon types.NamespacedName) (bool, error) {
+	if mon.Namespace == fight.Namespace {
+		return true, nil
+	}
+
+	var grants kubemonv1.FightGrantList
+	if err := c.List(ctx, &grants, client.InNamespace(mon.Namespace)); err != nil {
+		return false, err
+	}
+
+	for _, grant := range grants.Items {
+		if len(grant.Spec.KubeMons) > 0 && !slices.Contains(grant.Spec.KubeMons, mon.Name) {
+			continue
+		}
+		for _, from := range grant.Spec.From {
+			if from.Namespace == fight.Namespace {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	defaultSpawnInterval = 5 * time.Minute
+	defaultDespawnAfter  = time.Hour
+)
+
+// HabitatReconciler reconciles a Habitat object
+type HabitatReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=habitats,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=habitats/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=habitats/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fights,verbs=get;list;watch
+
+func (r *HabitatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var habitat kubemonv1.Habitat
+	if err := r.Get(ctx, req.NamespacedName, &habitat); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find Habitat")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if habitat.DeletionTimestamp != nil {
+		log.V(1).Info("Habitat is marked for deletion, stop reconciling")
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	var mons kubemonv1.KubeMonList
+	if err := r.List(ctx, &mons, client.InNamespace(habitat.Namespace), client.MatchingLabels{kubemonv1.HabitatLabel: habitat.Name}); err != nil {
+		log.Error(err, "Could not list KubeMons of Habitat")
+		return ctrl.Result{}, err
+	}
+
+	despawnAfter := durationOrDefault(habitat.Spec.DespawnAfter, defaultDespawnAfter)
+	spawnInterval := durationOrDefault(habitat.Spec.SpawnInterval, defaultSpawnInterval)
+	var requeueAfter time.Duration
+	requeueAt := func(d time.Duration) {
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	var population int32
+	for i := range mons.Items {
+		mon := &mons.Items[i]
+		if mon.Spec.Owner != "" || mon.DeletionTimestamp != nil {
+			continue
+		}
+
+		remaining := time.Until(lastEngaged(mon).Add(despawnAfter))
+		if remaining > 0 {
+			population++
+			requeueAt(remaining)
+			continue
+		}
+
+		fighting, err := r.isFighting(ctx, mon)
+		if err != nil {
+			log.Error(err, "Could not get Fight of KubeMon", "KubeMon", mon.Name)
+			return ctrl.Result{}, err
+		}
+		if fighting {
+			population++
+			requeueAt(spawnInterval)
+			continue
+		}
+
+		log.Info("Despawning wild KubeMon", "KubeMon", mon.Name)
+		if err := r.Delete(ctx, mon); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Could not despawn KubeMon", "KubeMon", mon.Name)
+			return ctrl.Result{}, err
+		}
+	}
+
+	status := habitat.Status.DeepCopy()
+	if population < habitat.Spec.PopulationCap {
+		if status.LastSpawn == nil || time.Since(status.LastSpawn.Time) >= spawnInterval {
+			mon, err := r.spawn(ctx, &habitat)
+			if err != nil {
+				log.Error(err, "Could not spawn KubeMon")
+				return ctrl.Result{}, err
+			}
+			log.Info("Spawned wild KubeMon", "KubeMon", mon.Name, "Species", mon.Spec.Species)
+
+			population++
+			status.LastSpawn = ptr.To(metav1.Now())
+		}
+		if population < habitat.Spec.PopulationCap {
+			requeueAt(time.Until(status.LastSpawn.Add(spawnInterval)))
+		}
+	}
+	status.Population = population
+
+	if !equality.Semantic.DeepEqual(status, &habitat.Status) {
+		habitat.Status = *status
+		if err := r.Status().Update(ctx, &habitat); err != nil {
+			log.Error(err, "Could not update status of Habitat")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// spawn creates a wild KubeMon of a random Species of the Habitat
+func (r *HabitatReconciler) spawn(ctx context.Context, habitat *kubemonv1.Habitat) (*kubemonv1.KubeMon, error) {
+	species := pickSpecies(habitat.Spec.Species)
+
+	level := species.MinLevel
+	if species.MaxLevel > species.MinLevel {
+		level += rand.Int31n(species.MaxLevel - species.MinLevel + 1)
+	}
+
+	mon := &kubemonv1.KubeMon{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    habitat.Namespace,
+			GenerateName: strings.ToLower(habitat.Name+"-"+species.Name) + "-",
+			Labels:       map[string]string{kubemonv1.HabitatLabel: habitat.Name},
+		},
+		Spec: kubemonv1.KubeMonSpec{
+			Species:      species.Name,
+			Strength:     1,
+			Moves:        species.Moves,
+			InitialLevel: ptr.To(max(level, 1)),
+		},
+	}
+	if err := controllerutil.SetControllerReference(habitat, mon, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	if err := r.Create(ctx, mon); err != nil {
+		return nil, err
+	}
+	return mon, nil
+}
+
+// isFighting reports whether a KubeMon is claimed by a Fight which has not ended yet
+func (r *HabitatReconciler) isFighting(ctx context.Context, mon *kubemonv1.KubeMon) (bool, error) {
+	current := mon.Status.CurrentFight
+	if current == nil {
+		return false, nil
+	}
+
+	var fight kubemonv1.Fight
+	if err := r.Get(ctx, types.NamespacedName{Namespace: current.Namespace, Name: current.Name}, &fight); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return fight.IsActive(), nil
+}
+
+// lastEngaged returns the time a wild KubeMon was last engaged, i.e. the time it spawned or last left a Fight
+func lastEngaged(mon *kubemonv1.KubeMon) time.Time {
+	last := mon.CreationTimestamp.Time
+	if battle := meta.FindStatusCondition(mon.Status.Conditions, kubemonv1.KubeMonConditionInBattle); battle != nil && battle.LastTransitionTime.After(last) {
+		last = battle.LastTransitionTime.Time
+	}
+	return last
+}
+
+// pickSpecies picks a random Species, weighted by the weights of the Species
+func pickSpecies(species []kubemonv1.HabitatSpecies) kubemonv1.HabitatSpecies {
+	var total int32
+	for _, s := range species {
+		total += max(s.Weight, 1)
+	}
+
+	n := rand.Int31n(total)
+	for _, s := range species {
+		n -= max(s.Weight, 1)
+		if n < 0 {
+			return s
+		}
+	}
+	return species[len(species)-1]
+}
+
+func durationOrDefault(d *metav1.Duration, def time.Duration) time.Duration {
+	if d == nil || d.Duration <= 0 {
+		return def
+	}
+	return d.Duration
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HabitatReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.Habitat{}).
+		Owns(&kubemonv1.KubeMon{}).
+		Complete(r)
+}
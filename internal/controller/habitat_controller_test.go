@@ -0,0 +1,156 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var _ = Describe("Habitat Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		habitat := &kubemonv1.Habitat{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind Habitat")
+			err := k8sClient.Get(ctx, typeNamespacedName, habitat)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &kubemonv1.Habitat{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: kubemonv1.HabitatSpec{
+						Species:       []kubemonv1.HabitatSpecies{{Name: "podling", Weight: 1, MinLevel: 2, MaxLevel: 5}},
+						PopulationCap: 1,
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &kubemonv1.Habitat{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance Habitat")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &HabitatReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Spawning a wild KubeMon")
+			mons := &kubemonv1.KubeMonList{}
+			Expect(k8sClient.List(ctx, mons, client.InNamespace("default"), client.MatchingLabels{kubemonv1.HabitatLabel: resourceName})).To(Succeed())
+			Expect(mons.Items).To(HaveLen(1))
+			Expect(mons.Items[0].Spec.Owner).To(BeEmpty())
+			Expect(*mons.Items[0].Spec.InitialLevel).To(BeNumerically(">=", 2))
+			Expect(*mons.Items[0].Spec.InitialLevel).To(BeNumerically("<=", 5))
+		})
+	})
+
+	Context("When wild KubeMons stayed longer than despawnAfter", func() {
+		ctx := context.Background()
+
+		It("should only despawn the KubeMons which have not been engaged since", func() {
+			longAgo := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+			wild := func(name string, status kubemonv1.KubeMonStatus) *kubemonv1.KubeMon {
+				return &kubemonv1.KubeMon{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						Namespace:         "default",
+						CreationTimestamp: longAgo,
+						Labels:            map[string]string{kubemonv1.HabitatLabel: "meadow"},
+					},
+					Spec:   kubemonv1.KubeMonSpec{Species: "podling", Strength: 1},
+					Status: status,
+				}
+			}
+			inBattle := func(status metav1.ConditionStatus, since time.Duration) []metav1.Condition {
+				return []metav1.Condition{{
+					Type:               kubemonv1.KubeMonConditionInBattle,
+					Status:             status,
+					Reason:             "Test",
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+				}}
+			}
+			fight := kubemonv1.FightReference{Namespace: "default", Name: "meadow-fight"}
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Habitat{}, &kubemonv1.KubeMon{}, &kubemonv1.Fight{}).
+				WithObjects(
+					&kubemonv1.Habitat{
+						ObjectMeta: metav1.ObjectMeta{Name: "meadow", Namespace: "default"},
+						Spec:       kubemonv1.HabitatSpec{DespawnAfter: &metav1.Duration{Duration: time.Hour}},
+					},
+					&kubemonv1.Fight{
+						ObjectMeta: metav1.ObjectMeta{Name: fight.Name, Namespace: fight.Namespace},
+						Status:     kubemonv1.FightStatus{Phase: kubemonv1.FightInProgress},
+					},
+					wild("idle-mon", kubemonv1.KubeMonStatus{}),
+					wild("recently-fought-mon", kubemonv1.KubeMonStatus{Conditions: inBattle(metav1.ConditionFalse, 10*time.Minute)}),
+					wild("fighting-mon", kubemonv1.KubeMonStatus{CurrentFight: &fight, Conditions: inBattle(metav1.ConditionTrue, 2*time.Hour)}),
+				).
+				Build()
+
+			r := &HabitatReconciler{Client: c, Scheme: c.Scheme()}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "meadow", Namespace: "default"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			mons := &kubemonv1.KubeMonList{}
+			Expect(c.List(ctx, mons)).To(Succeed())
+			var names []string
+			for _, mon := range mons.Items {
+				names = append(names, mon.Name)
+			}
+			Expect(names).To(ConsistOf("recently-fought-mon", "fighting-mon"))
+		})
+	})
+})
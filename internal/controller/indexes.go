@@ -0,0 +1,79 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	kubeMonSpeciesField   = ".spec.species"
+	kubeMonMovesField     = ".spec.moves"
+	kubeMonOwnerField     = ".spec.owner"
+	fightActionFightField = ".spec.fight"
+	fightKubeMonsField    = kubemonv1.FightKubeMonsField
+	fightNamespacesField  = ".namespaces"
+)
+
+// SetupFieldIndexes registers the field indexes shared by the controllers with the Manager.
+// It has to be called before the controllers are set up.
+func SetupFieldIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	indexer := mgr.GetFieldIndexer()
+
+	if err := indexer.IndexField(ctx, &kubemonv1.KubeMon{}, kubeMonSpeciesField, func(obj client.Object) []string {
+		return []string{obj.(*kubemonv1.KubeMon).Spec.Species}
+	}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubemonv1.KubeMon{}, kubeMonMovesField, func(obj client.Object) []string {
+		return obj.(*kubemonv1.KubeMon).Spec.Moves
+	}); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubemonv1.KubeMon{}, kubeMonOwnerField, kubeMonOwner); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubemonv1.FightAction{}, fightActionFightField, fightActionFight); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubemonv1.Fight{}, fightKubeMonsField, kubemonv1.IndexFightKubeMons); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(ctx, &kubemonv1.Fight{}, fightNamespacesField, func(obj client.Object) []string {
+		fight := obj.(*kubemonv1.Fight)
+		return []string{kubeMonNamespace(fight, 1), kubeMonNamespace(fight, 2)}
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// fightActionFight returns the Fight a FightAction was submitted for, which FightActions are indexed by in fightActionFightField
+func fightActionFight(obj client.Object) []string {
+	action := obj.(*kubemonv1.FightAction)
+	return []string{fightKey(namespaceOrDefault(action.Spec.FightNamespace, action.Namespace), action.Spec.Fight)}
+}
+
+// kubeMonOwner returns the Trainer owning a KubeMon, which KubeMons are indexed by in kubeMonOwnerField
+func kubeMonOwner(obj client.Object) []string {
+	return []string{obj.(*kubemonv1.KubeMon).Spec.Owner}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+var (
+	ErrItemNotInInventory = errors.New("item is not in the inventory of the owner")
+)
+
+// useItem applies an Item from the Inventory of the KubeMon's owner to the KubeMon.
+// The Item is taken out of the Inventory with the given receipt before its effect is applied,
+// so retrying after the effect could not be applied does not take another one.
+func useItem(ctx context.Context, c client.Client, namespace string, mon *kubemon.KubeMon, itemName, receipt string) error {
+	item := &kubemonv1.Item{}
+	if err := c.Get(ctx, types.NamespacedName{Name: itemName}, item); err != nil {
+		return err
+	}
+
+	if !mon.CanUseItem(item) {
+		return kubemon.ErrItemNoEffect
+	}
+
+	if err := takeItem(ctx, c, namespace, mon.Owner(), itemName, receipt); err != nil {
+		return err
+	}
+
+	return mon.UseItem(item)
+}
+
+// useItemReceipt returns the receipt of the Item taken for the use-item action of the KubeMon.
+// It is forgotten once the action is reset, so the next action takes a new Item.
+func useItemReceipt(mon *kubemon.KubeMon) string {
+	return string(mon.Object().UID) + "/use-item"
+}
+
+// checkItem returns the Item, if the owner of the KubeMon has it in its Inventory and it would have an effect on the KubeMon.
+// The Item is not taken out of the Inventory.
+func checkItem(ctx context.Context, c client.Client, namespace string, mon *kubemon.KubeMon, itemName string) (*kubemonv1.Item, error) {
+	item := &kubemonv1.Item{}
+	if err := c.Get(ctx, types.NamespacedName{Name: itemName}, item); err != nil {
+		return nil, err
+	}
+
+	if !mon.CanUseItem(item) {
+		return nil, kubemon.ErrItemNoEffect
+	}
+
+	if mon.Owner() == "" {
+		return nil, ErrItemNotInInventory
+	}
+	inventory := &kubemonv1.Inventory{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: mon.Owner()}, inventory); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, ErrItemNotInInventory
+		}
+		return nil, err
+	}
+	if !slices.ContainsFunc(inventory.Status.Items, func(stack kubemonv1.ItemStack) bool { return stack.Name == itemName && stack.Quantity > 0 }) {
+		return nil, ErrItemNotInInventory
+	}
+	return item, nil
+}
+
+// takeItem removes a single Item from the Inventory of an owner.
+// If a receipt is given, it is recorded along with the change, so the same Item is never taken twice for it.
+func takeItem(ctx context.Context, c client.Client, namespace, owner, itemName, receipt string) error {
+	if owner == "" {
+		return ErrItemNotInInventory
+	}
+
+	inventory := &kubemonv1.Inventory{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: owner}, inventory); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return ErrItemNotInInventory
+		}
+		return err
+	}
+
+	if receipt != "" && slices.Contains(inventory.Status.Receipts, receipt) {
+		return nil
+	}
+
+	for i := range inventory.Status.Items {
+		stack := &inventory.Status.Items[i]
+		if stack.Name != itemName || stack.Quantity <= 0 {
+			continue
+		}
+
+		stack.Quantity--
+		if receipt != "" {
+			inventory.Status.Receipts = append(inventory.Status.Receipts, receipt)
+		}
+		return c.Status().Update(ctx, inventory)
+	}
+	return ErrItemNotInInventory
+}
+
+// addItem puts Items into the Inventory of an owner, creating the Inventory if it does not exist yet.
+// The receipt is recorded along with the Items, so the same Items are never added twice for it.
+func addItem(ctx context.Context, c client.Client, namespace, owner, itemName string, quantity int32, receipt string) error {
+	inventory := &kubemonv1.Inventory{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: owner}, inventory)
+	if apierrors.IsNotFound(err) {
+		inventory = &kubemonv1.Inventory{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: owner},
+		}
+		if err := c.Create(ctx, inventory); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if slices.Contains(inventory.Status.Receipts, receipt) {
+		return nil
+	}
+
+	inventory.Status.Items = addToStacks(inventory.Status.Items, itemName, quantity)
+	inventory.Status.Receipts = append(inventory.Status.Receipts, receipt)
+	return c.Status().Update(ctx, inventory)
+}
+
+// forgetReceipt removes a receipt from the Inventory of an owner, once the change it was recorded for is completed
+func forgetReceipt(ctx context.Context, c client.Client, namespace, owner, receipt string) error {
+	inventory := &kubemonv1.Inventory{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: owner}, inventory); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	i := slices.Index(inventory.Status.Receipts, receipt)
+	if i < 0 {
+		return nil
+	}
+	inventory.Status.Receipts = slices.Delete(inventory.Status.Receipts, i, i+1)
+	return c.Status().Update(ctx, inventory)
+}
+
+// addToStacks adds a number of Items to the matching ItemStack, appending a new one if there is none
+func addToStacks(stacks []kubemonv1.ItemStack, itemName string, quantity int32) []kubemonv1.ItemStack {
+	for i := range stacks {
+		if stacks[i].Name == itemName {
+			stacks[i].Quantity += quantity
+			return stacks
+		}
+	}
+	return append(stacks, kubemonv1.ItemStack{Name: itemName, Quantity: quantity})
+}
@@ -19,21 +19,29 @@ package controller
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
 	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
 )
 
 // KubeMonReconciler reconciles a KubeMon object
 type KubeMonReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 var (
@@ -43,6 +51,13 @@ var (
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=species,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=moves,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=items,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 func (r *KubeMonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -54,6 +69,11 @@ func (r *KubeMonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{Requeue: false}, nil
 		}
 
+		if err == kubemon.ErrSpeciesNotFound {
+			log.Info("Species of KubeMon does not exist, waiting for it to be created")
+			return ctrl.Result{}, nil
+		}
+
 		if client.IgnoreNotFound(err) == nil {
 			log.Info("Could not find KubeMon")
 		}
@@ -62,19 +82,100 @@ func (r *KubeMonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 	log.Info("Got KubeMon object")
 
+	if err := mon.ResolveTrainer(); err != nil {
+		log.Error(err, "Could not resolve Trainer of KubeMon")
+		return ctrl.Result{}, err
+	}
+
+	// Fights own the state of their KubeMons until they release them, which requeues the KubeMon
+	if mon.GetAction() != "" && mon.CurrentFight() != nil {
+		log.Info("KubeMon is fighting, deferring its action until it leaves the Fight", "Action", mon.GetAction(), "Fight", mon.CurrentFight().String())
+		return r.evolve(ctx, mon)
+	}
+
 	if mon.GetAction() == kubemon.KubeMonActionHeal {
 		if err := mon.AddHealth(10); err != nil {
 			return ctrl.Result{}, err
 		}
 
+		if err := mon.RestorePP(); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := mon.CureAilment(); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := mon.AddHealFriendship(time.Now()); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := mon.ResetAction(); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(mon.Object(), corev1.EventTypeNormal, EventReasonHealed, "%s was healed to %d HP", mon.Name(), mon.HP())
+		metrics.Heals.Inc()
+	}
+
+	if itemName, ok := strings.CutPrefix(mon.GetAction(), kubemon.KubeMonActionUseItemPrefix); ok {
+		species := mon.Species().Name
+		if err := useItem(ctx, r.Client, req.Namespace, mon, itemName, useItemReceipt(mon)); err != nil {
+			if client.IgnoreNotFound(err) != nil && err != ErrItemNotInInventory && err != kubemon.ErrItemNoEffect {
+				return ctrl.Result{}, err
+			}
+			log.Info("Could not use Item", "Item", itemName, "Reason", err.Error())
+		}
+
 		if err := mon.ResetAction(); err != nil {
 			return ctrl.Result{}, err
 		}
+		if mon.Species().Name != species {
+			r.recordEvolution(mon, species)
+		}
 	}
 
+	// The receipt of the Item is only forgotten once the action is reset, so retrying the action never takes another Item
+	if mon.Owner() != "" && !strings.HasPrefix(mon.GetAction(), kubemon.KubeMonActionUseItemPrefix) {
+		if err := forgetReceipt(ctx, r.Client, req.Namespace, mon.Owner(), useItemReceipt(mon)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.evolve(ctx, mon)
+}
+
+// evolve evolves the KubeMon if the triggers of one of the evolutions of its Species, which do not need an Item, are met.
+// KubeMons which would evolve at another time of day are requeued for when it starts.
+func (r *KubeMonReconciler) evolve(ctx context.Context, mon *kubemon.KubeMon) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	now := time.Now()
+	if evolution := mon.Evolution(now, ""); evolution != nil {
+		species := mon.Species().Name
+		if err := mon.Evolve(evolution); err != nil {
+			if err == kubemon.ErrEvolutionNotFound {
+				log.Info("Species the KubeMon evolves into does not exist", "Species", evolution.Species)
+				return ctrl.Result{}, nil
+			}
+			log.Error(err, "Could not evolve KubeMon", "Species", evolution.Species)
+			return ctrl.Result{}, err
+		}
+		r.recordEvolution(mon, species)
+		// The new Species may evolve further. Chains of evolutions end, as KubeMons never evolve back into a Species they had
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if mon.EvolvesAtOtherTimeOfDay(now) {
+		return ctrl.Result{RequeueAfter: kubemon.UntilTimeOfDayChanges(now)}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
+func (r *KubeMonReconciler) recordEvolution(mon *kubemon.KubeMon, previous string) {
+	r.Recorder.Eventf(mon.Object(), corev1.EventTypeNormal, EventReasonEvolved, "%s evolved from %s into %s", mon.Name(), previous, mon.Species().Name)
+	metrics.Evolutions.WithLabelValues(previous, mon.Species().Name).Inc()
+}
+
 func (r *KubeMonReconciler) getKubeMon(ctx context.Context, name types.NamespacedName) (*kubemon.KubeMon, error) {
 	apiMon := &kubemonv1.KubeMon{}
 	if err := r.Get(ctx, name, apiMon); err != nil {
@@ -91,9 +192,41 @@ func (r *KubeMonReconciler) getKubeMon(ctx context.Context, name types.Namespace
 	return mon, nil
 }
 
+// kubeMonsForSpecies enqueues all KubeMons of a Species, so they get initialized once it exists
+func (r *KubeMonReconciler) kubeMonsForSpecies(ctx context.Context, species client.Object) []reconcile.Request {
+	return r.kubeMonsMatching(ctx, client.MatchingFields{kubeMonSpeciesField: species.GetName()})
+}
+
+// kubeMonsForMove enqueues all KubeMons knowing a Move, so they pick up changes to it
+func (r *KubeMonReconciler) kubeMonsForMove(ctx context.Context, move client.Object) []reconcile.Request {
+	return r.kubeMonsMatching(ctx, client.MatchingFields{kubeMonMovesField: move.GetName()})
+}
+
+// kubeMonsForTrainer enqueues all KubeMons owned by a Trainer, so they notice it being created or deleted
+func (r *KubeMonReconciler) kubeMonsForTrainer(ctx context.Context, trainer client.Object) []reconcile.Request {
+	return r.kubeMonsMatching(ctx, client.InNamespace(trainer.GetNamespace()), client.MatchingFields{kubeMonOwnerField: trainer.GetName()})
+}
+
+func (r *KubeMonReconciler) kubeMonsMatching(ctx context.Context, opts ...client.ListOption) []reconcile.Request {
+	var mons kubemonv1.KubeMonList
+	if err := r.List(ctx, &mons, opts...); err != nil {
+		log.FromContext(ctx).Error(err, "Could not list KubeMons")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(mons.Items))
+	for _, mon := range mons.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&mon)})
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *KubeMonReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kubemonv1.KubeMon{}).
+		Watches(&kubemonv1.Species{}, handler.EnqueueRequestsFromMapFunc(r.kubeMonsForSpecies)).
+		Watches(&kubemonv1.Move{}, handler.EnqueueRequestsFromMapFunc(r.kubeMonsForMove)).
+		Watches(&kubemonv1.Trainer{}, handler.EnqueueRequestsFromMapFunc(r.kubeMonsForTrainer)).
 		Complete(r)
 }
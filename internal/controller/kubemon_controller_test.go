@@ -18,16 +18,27 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemonpkg "github.com/memeToasty/kubemon/internal/kubemon"
+	"github.com/memeToasty/kubemon/internal/metrics"
 )
 
 var _ = Describe("KubeMon Controller", func() {
@@ -69,8 +80,9 @@ var _ = Describe("KubeMon Controller", func() {
 		It("should successfully reconcile the resource", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &KubeMonReconciler{
-				Client: k8sClient,
-				Scheme: k8sClient.Scheme(),
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: &record.FakeRecorder{},
 			}
 
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
@@ -80,5 +92,216 @@ var _ = Describe("KubeMon Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+		It("should report a missing species", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &KubeMonReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: &record.FakeRecorder{},
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &kubemonv1.KubeMon{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(resource.Status.Conditions, kubemonv1.KubeMonConditionSpeciesResolved)).To(BeTrue())
+			Expect(resource.Status.HP).To(BeNil())
+		})
+	})
+
+	Context("When healing a KubeMon", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "healed-mon", Namespace: "default"}
+
+		heal := func(c client.Client, recorder *record.FakeRecorder) *kubemonv1.KubeMon {
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, name, mon)).To(Succeed())
+			mon.Annotations = map[string]string{kubemonpkg.KubeMonActionAnnotation: kubemonpkg.KubeMonActionHeal}
+			Expect(c.Update(ctx, mon)).To(Succeed())
+
+			r := &KubeMonReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, name, mon)).To(Succeed())
+			return mon
+		}
+
+		It("should defer healing during a Fight and only raise the friendship once per cooldown", func() {
+			fight := kubemonv1.FightReference{Namespace: "default", Name: "ongoing-fight"}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.KubeMon{}).
+				WithObjects(
+					&kubemonv1.Species{
+						ObjectMeta: metav1.ObjectMeta{Name: "healed-species"},
+						Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+						Spec:       kubemonv1.KubeMonSpec{Species: "healed-species", Strength: 1, InitialLevel: ptr.To(int32(50))},
+						Status:     kubemonv1.KubeMonStatus{HP: ptr.To(int32(1)), CurrentFight: &fight},
+					},
+				).
+				Build()
+
+			recorder := record.NewFakeRecorder(10)
+
+			By("keeping the action while the KubeMon is fighting")
+			mon := heal(c, recorder)
+			Expect(mon.Annotations).To(HaveKey(kubemonpkg.KubeMonActionAnnotation))
+			Expect(*mon.Status.HP).To(Equal(int32(1)))
+			Expect(receivedEvents(recorder)).To(BeEmpty())
+
+			By("healing the KubeMon once the Fight released it")
+			heals := testutil.ToFloat64(metrics.Heals)
+			mon.Status.CurrentFight = nil
+			Expect(c.Status().Update(ctx, mon)).To(Succeed())
+			mon = heal(c, recorder)
+			Expect(testutil.ToFloat64(metrics.Heals)).To(Equal(heals + 1))
+			Expect(mon.Annotations).NotTo(HaveKey(kubemonpkg.KubeMonActionAnnotation))
+			Expect(*mon.Status.HP).To(Equal(int32(11)))
+			Expect(*mon.Status.Friendship).To(Equal(int32(kubemonpkg.InitialFriendship + kubemonpkg.FriendshipHealed)))
+			Expect(receivedEvents(recorder)).To(Equal([]string{"Normal Healed healed-mon was healed to 11 HP"}))
+
+			By("not raising the friendship again within the cooldown")
+			mon = heal(c, recorder)
+			Expect(*mon.Status.HP).To(Equal(int32(21)))
+			Expect(*mon.Status.Friendship).To(Equal(int32(kubemonpkg.InitialFriendship + kubemonpkg.FriendshipHealed)))
+		})
+	})
+
+	Context("When resolving the Species and Moves of a KubeMon", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "resolved-mon", Namespace: "default"}
+
+		It("should initialize the KubeMon from its Species, Moves and Trainer", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.KubeMon{}).
+				WithObjects(
+					&kubemonv1.Species{
+						ObjectMeta: metav1.ObjectMeta{Name: "resolved-species"},
+						Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+					},
+					&kubemonv1.Move{
+						ObjectMeta: metav1.ObjectMeta{Name: "tackle"},
+						Spec:       kubemonv1.MoveSpec{Power: 40, PP: 35},
+					},
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "gary", Namespace: "default", UID: "gary-uid"}},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+						Spec: kubemonv1.KubeMonSpec{
+							Species:      "resolved-species",
+							Owner:        "gary",
+							Strength:     1,
+							Moves:        []string{"tackle"},
+							InitialLevel: ptr.To(int32(5)),
+						},
+					},
+				).
+				Build()
+
+			r := &KubeMonReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(10)}
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, name, mon)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(mon.Status.Conditions, kubemonv1.KubeMonConditionSpeciesResolved)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(mon.Status.Conditions, kubemonv1.KubeMonConditionMovesResolved)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(mon.Status.Conditions, kubemonv1.KubeMonConditionTrainerResolved)).To(BeTrue())
+
+			Expect(*mon.Status.Level).To(Equal(int32(5)))
+			Expect(*mon.Status.MaxHP).To(Equal(kubemonpkg.CalculateMaxHP(45, 5)))
+			Expect(*mon.Status.HP).To(Equal(*mon.Status.MaxHP))
+			Expect(*mon.Status.Attack).To(Equal(kubemonpkg.CalculateStat(49, 5)))
+			Expect(*mon.Status.Friendship).To(Equal(int32(kubemonpkg.InitialFriendship)))
+			Expect(mon.Status.Moves).To(ConsistOf(kubemonv1.KubeMonMove{Name: "tackle", PP: 35}))
+
+			Expect(mon.OwnerReferences).To(HaveLen(1))
+			Expect(mon.OwnerReferences[0].Name).To(Equal("gary"))
+			Expect(mon.OwnerReferences[0].UID).To(Equal(types.UID("gary-uid")))
+		})
+	})
+
+	Context("When using an Item", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "potion-mon", Namespace: "default"}
+		inventoryName := types.NamespacedName{Name: "tobi", Namespace: "default"}
+
+		It("should take only one Item when the effect has to be applied again", func() {
+			// The first status update of the KubeMon after the Item was taken fails
+			var taken, failed bool
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.KubeMon{}, &kubemonv1.Inventory{}).
+				WithObjects(
+					&kubemonv1.Species{
+						ObjectMeta: metav1.ObjectMeta{Name: "potion-species"},
+						Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+					},
+					&kubemonv1.Item{
+						ObjectMeta: metav1.ObjectMeta{Name: "potion"},
+						Spec:       kubemonv1.ItemSpec{Category: kubemonv1.ItemCategoryPotion, HealAmount: 20},
+					},
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"}},
+					&kubemonv1.Inventory{
+						ObjectMeta: metav1.ObjectMeta{Name: inventoryName.Name, Namespace: inventoryName.Namespace},
+						Status:     kubemonv1.InventoryStatus{Items: []kubemonv1.ItemStack{{Name: "potion", Quantity: 2}}},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        name.Name,
+							Namespace:   name.Namespace,
+							UID:         "potion-mon-uid",
+							Annotations: map[string]string{kubemonpkg.KubeMonActionAnnotation: kubemonpkg.KubeMonActionUseItemPrefix + "potion"},
+						},
+						Spec:   kubemonv1.KubeMonSpec{Species: "potion-species", Owner: "tobi", Strength: 1, InitialLevel: ptr.To(int32(50))},
+						Status: kubemonv1.KubeMonStatus{HP: ptr.To(int32(1))},
+					},
+				).
+				WithInterceptorFuncs(interceptor.Funcs{
+					SubResourceUpdate: func(ctx context.Context, c client.Client, subResource string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+						switch obj.(type) {
+						case *kubemonv1.Inventory:
+							taken = true
+						case *kubemonv1.KubeMon:
+							if taken && !failed {
+								failed = true
+								return fmt.Errorf("injected failure")
+							}
+						}
+						return c.SubResource(subResource).Update(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			r := &KubeMonReconciler{Client: c, Scheme: c.Scheme(), Recorder: record.NewFakeRecorder(10)}
+
+			By("failing to apply the effect after taking the Item")
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).To(HaveOccurred())
+
+			inventory := &kubemonv1.Inventory{}
+			Expect(c.Get(ctx, inventoryName, inventory)).To(Succeed())
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 1}))
+
+			By("applying the effect on the retry without taking another Item")
+			_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+			Expect(err).NotTo(HaveOccurred())
+
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, name, mon)).To(Succeed())
+			Expect(mon.Annotations).NotTo(HaveKey(kubemonpkg.KubeMonActionAnnotation))
+			Expect(*mon.Status.HP).To(Equal(int32(21)))
+
+			Expect(c.Get(ctx, inventoryName, inventory)).To(Succeed())
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 1}))
+			Expect(inventory.Status.Receipts).To(BeEmpty())
+		})
 	})
 })
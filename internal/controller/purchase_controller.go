@@ -0,0 +1,284 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	// PurchaseLabel marks the Transaction paying for a Purchase with the name of the Purchase
+	PurchaseLabel = "kubemon.memetoasty.github.com/purchase"
+	// PurchaseUIDLabel marks the Transaction paying for a Purchase with the UID of the Purchase
+	PurchaseUIDLabel = "kubemon.memetoasty.github.com/purchase-uid"
+)
+
+var (
+	PurchaseMessageShopNotFound    = "Shop %s does not exist"
+	PurchaseMessageItemNotSold     = "Shop %s does not sell %s"
+	PurchaseMessageBuyerNotFound   = "Trainer %s does not exist"
+	PurchaseMessageOutOfStock      = "Shop %s has only %d %s left"
+	PurchaseMessageReserved        = "Reserved %d %s for %d coins"
+	PurchaseMessagePaymentFailed   = "Payment failed: %s"
+	PurchaseMessagePaymentMismatch = "Transaction %s does not pay for this Purchase"
+	PurchaseMessagePaid            = "Paid %d coins"
+	PurchaseMessageCompleted       = "Bought %d %s for %d coins"
+	PurchaseTransactionReason      = "Bought %d %s at Shop %s"
+	purchaseTransactionNamePostfix = "-payment"
+)
+
+// PurchaseReconciler reconciles a Purchase object.
+// Purchases are processed one after another by a single worker, and the stock of Shops is read through APIReader,
+// so that no Item can be sold twice. The Items sold are recorded in the status of the Shop, deleting a Purchase does not restock them.
+type PurchaseReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads directly from the API server, bypassing the cache of the Client
+	APIReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=purchases,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=purchases/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=purchases/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=shops,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=shops/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=inventories/status,verbs=get;update;patch
+
+func (r *PurchaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var purchase kubemonv1.Purchase
+	if err := r.APIReader.Get(ctx, req.NamespacedName, &purchase); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find Purchase")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if purchase.DeletionTimestamp != nil {
+		log.V(1).Info("Purchase is marked for deletion, stop reconciling")
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	var err error
+	switch purchase.Status.Phase {
+	case "", kubemonv1.PurchasePending:
+		err = r.reserve(ctx, &purchase)
+	case kubemonv1.PurchaseReserved:
+		err = r.pay(ctx, &purchase)
+	case kubemonv1.PurchasePaid:
+		err = r.deliver(ctx, &purchase)
+	case kubemonv1.PurchaseCompleted:
+		err = forgetReceipt(ctx, r.Client, purchase.Namespace, purchase.Spec.Buyer, string(purchase.UID))
+	default:
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		log.Error(err, "Could not process Purchase", "Phase", purchase.Status.Phase)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reserve takes the Items out of the stock of the Shop
+func (r *PurchaseReconciler) reserve(ctx context.Context, purchase *kubemonv1.Purchase) error {
+	spec := purchase.Spec
+
+	var shop kubemonv1.Shop
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: purchase.Namespace, Name: spec.Shop}, &shop)
+	if apierrors.IsNotFound(err) {
+		return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessageShopNotFound, spec.Shop))
+	} else if err != nil {
+		return err
+	}
+
+	var offer *kubemonv1.ShopItem
+	for i := range shop.Spec.Items {
+		if shop.Spec.Items[i].Name == spec.Item {
+			offer = &shop.Spec.Items[i]
+		}
+	}
+	if offer == nil {
+		return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessageItemNotSold, spec.Shop, spec.Item))
+	}
+
+	var buyer kubemonv1.Trainer
+	err = r.APIReader.Get(ctx, types.NamespacedName{Namespace: purchase.Namespace, Name: spec.Buyer}, &buyer)
+	if apierrors.IsNotFound(err) {
+		return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessageBuyerNotFound, spec.Buyer))
+	} else if err != nil {
+		return err
+	}
+
+	if !slices.Contains(shop.Status.Reservations, purchase.UID) {
+		for _, stack := range shopStock(&shop.Spec, shop.Status.Sold) {
+			if stack.Name == spec.Item && stack.Quantity < spec.Quantity {
+				return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessageOutOfStock, spec.Shop, stack.Quantity, spec.Item))
+			}
+		}
+
+		shop.Status.Sold = addToStacks(shop.Status.Sold, spec.Item, spec.Quantity)
+		shop.Status.Reservations = append(shop.Status.Reservations, purchase.UID)
+		shop.Status.Stock = shopStock(&shop.Spec, shop.Status.Sold)
+		if err := r.Status().Update(ctx, &shop); err != nil {
+			return err
+		}
+	}
+
+	purchase.Status.Phase = kubemonv1.PurchaseReserved
+	purchase.Status.Price = offer.Price * int64(spec.Quantity)
+	purchase.Status.ReservedAt = ptr.To(metav1.Now())
+	purchase.Status.Message = fmt.Sprintf(PurchaseMessageReserved, spec.Quantity, spec.Item, purchase.Status.Price)
+	return r.Status().Update(ctx, purchase)
+}
+
+// pay creates the Transaction paying for the Purchase and waits for it to be processed.
+// The Transaction is named after the UID of the Purchase, so a Purchase recreated under the same name never accepts an older payment.
+func (r *PurchaseReconciler) pay(ctx context.Context, purchase *kubemonv1.Purchase) error {
+	transaction := &kubemonv1.Transaction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: purchase.Namespace,
+			Name:      string(purchase.UID) + purchaseTransactionNamePostfix,
+		},
+	}
+
+	err := r.APIReader.Get(ctx, client.ObjectKeyFromObject(transaction), transaction)
+	if apierrors.IsNotFound(err) {
+		transaction.Labels = map[string]string{PurchaseLabel: purchase.Name, PurchaseUIDLabel: string(purchase.UID)}
+		transaction.Spec = kubemonv1.TransactionSpec{
+			From:   purchase.Spec.Buyer,
+			Amount: purchase.Status.Price,
+			Reason: fmt.Sprintf(PurchaseTransactionReason, purchase.Spec.Quantity, purchase.Spec.Item, purchase.Spec.Shop),
+		}
+		if err := r.Create(ctx, transaction); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+
+		purchase.Status.Transaction = transaction.Name
+		return r.Status().Update(ctx, purchase)
+	} else if err != nil {
+		return err
+	}
+
+	if !paysFor(transaction, purchase) {
+		if err := r.release(ctx, purchase); err != nil {
+			return err
+		}
+		return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessagePaymentMismatch, transaction.Name))
+	}
+
+	switch transaction.Status.Phase {
+	case kubemonv1.TransactionFailed:
+		if err := r.release(ctx, purchase); err != nil {
+			return err
+		}
+		return r.fail(ctx, purchase, fmt.Sprintf(PurchaseMessagePaymentFailed, transaction.Status.Message))
+	case kubemonv1.TransactionCompleted:
+		purchase.Status.Phase = kubemonv1.PurchasePaid
+		purchase.Status.Message = fmt.Sprintf(PurchaseMessagePaid, transaction.Spec.Amount)
+		return r.Status().Update(ctx, purchase)
+	default:
+		return nil
+	}
+}
+
+// paysFor checks that a Transaction moves the price of the Purchase out of the wallet of its buyer
+func paysFor(transaction *kubemonv1.Transaction, purchase *kubemonv1.Purchase) bool {
+	return transaction.Labels[PurchaseUIDLabel] == string(purchase.UID) &&
+		transaction.Spec.From == purchase.Spec.Buyer &&
+		transaction.Spec.To == "" &&
+		transaction.Spec.Amount == purchase.Status.Price
+}
+
+// release puts the Items of a Purchase back into the stock of the Shop, unless the Shop has been restocked since they were reserved
+func (r *PurchaseReconciler) release(ctx context.Context, purchase *kubemonv1.Purchase) error {
+	var shop kubemonv1.Shop
+	if err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: purchase.Namespace, Name: purchase.Spec.Shop}, &shop); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	i := slices.Index(shop.Status.Reservations, purchase.UID)
+	if i < 0 {
+		return nil
+	}
+	shop.Status.Reservations = slices.Delete(shop.Status.Reservations, i, i+1)
+	shop.Status.Sold = addToStacks(shop.Status.Sold, purchase.Spec.Item, -purchase.Spec.Quantity)
+	shop.Status.Stock = shopStock(&shop.Spec, shop.Status.Sold)
+	return r.Status().Update(ctx, &shop)
+}
+
+// deliver puts the bought Items into the Inventory of the buyer.
+// The delivery is keyed to the Purchase, so retrying it after a failed status update does not deliver the Items twice.
+func (r *PurchaseReconciler) deliver(ctx context.Context, purchase *kubemonv1.Purchase) error {
+	if err := addItem(ctx, r.Client, purchase.Namespace, purchase.Spec.Buyer, purchase.Spec.Item, purchase.Spec.Quantity, string(purchase.UID)); err != nil {
+		return err
+	}
+
+	purchase.Status.Phase = kubemonv1.PurchaseCompleted
+	purchase.Status.Message = fmt.Sprintf(PurchaseMessageCompleted, purchase.Spec.Quantity, purchase.Spec.Item, purchase.Status.Price)
+	return r.Status().Update(ctx, purchase)
+}
+
+func (r *PurchaseReconciler) fail(ctx context.Context, purchase *kubemonv1.Purchase, message string) error {
+	log.FromContext(ctx).Info("Purchase failed", "Reason", message)
+
+	purchase.Status.Phase = kubemonv1.PurchaseFailed
+	purchase.Status.Message = message
+	return r.Status().Update(ctx, purchase)
+}
+
+// purchaseForTransaction enqueues the Purchase a Transaction pays for
+func (r *PurchaseReconciler) purchaseForTransaction(ctx context.Context, transaction client.Object) []reconcile.Request {
+	purchase, ok := transaction.GetLabels()[PurchaseLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: transaction.GetNamespace(),
+		Name:      purchase,
+	}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PurchaseReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.Purchase{}).
+		Watches(&kubemonv1.Transaction{}, handler.EnqueueRequestsFromMapFunc(r.purchaseForTransaction)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		Complete(r)
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var _ = Describe("Purchase Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		purchase := &kubemonv1.Purchase{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind Purchase")
+			err := k8sClient.Get(ctx, typeNamespacedName, purchase)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &kubemonv1.Purchase{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: kubemonv1.PurchaseSpec{
+						Shop:     "missing-shop",
+						Buyer:    "tobi",
+						Item:     "potion",
+						Quantity: 1,
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &kubemonv1.Purchase{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance Purchase")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &PurchaseReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Failing the Purchase at a missing Shop")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, purchase)).To(Succeed())
+			Expect(purchase.Status.Phase).To(Equal(kubemonv1.PurchaseFailed))
+		})
+	})
+
+	Context("When buying Items", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "buy-potions", Namespace: "default"}
+
+		It("should reserve, pay for and deliver the Items", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Purchase{}, &kubemonv1.Shop{}, &kubemonv1.Transaction{}, &kubemonv1.Inventory{}).
+				WithObjects(
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"}},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "tobi-payout", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{To: "tobi", Amount: 100, Fight: "won-fight"},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+					},
+					&kubemonv1.Shop{
+						ObjectMeta: metav1.ObjectMeta{Name: "mart", Namespace: "default"},
+						Spec:       kubemonv1.ShopSpec{Items: []kubemonv1.ShopItem{{Name: "potion", Price: 20, Stock: 5}}},
+					},
+					&kubemonv1.Purchase{
+						ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace, UID: "buy-potions-uid"},
+						Spec:       kubemonv1.PurchaseSpec{Shop: "mart", Buyer: "tobi", Item: "potion", Quantity: 2},
+					},
+				).
+				Build()
+
+			r := &PurchaseReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			reconcilePurchase := func() *kubemonv1.Purchase {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+				Expect(err).NotTo(HaveOccurred())
+
+				purchase := &kubemonv1.Purchase{}
+				Expect(c.Get(ctx, name, purchase)).To(Succeed())
+				return purchase
+			}
+
+			By("reserving the Items in the Shop")
+			purchase := reconcilePurchase()
+			Expect(purchase.Status.Phase).To(Equal(kubemonv1.PurchaseReserved))
+			Expect(purchase.Status.Price).To(Equal(int64(40)))
+
+			shop := &kubemonv1.Shop{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "mart", Namespace: "default"}, shop)).To(Succeed())
+			Expect(shop.Status.Stock).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 3}))
+			Expect(shop.Status.Reservations).To(ConsistOf(purchase.UID))
+
+			By("paying with a Transaction")
+			purchase = reconcilePurchase()
+			Expect(purchase.Status.Transaction).NotTo(BeEmpty())
+
+			transactionName := types.NamespacedName{Name: purchase.Status.Transaction, Namespace: "default"}
+			transactions := &TransactionReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			_, err := transactions.Reconcile(ctx, reconcile.Request{NamespacedName: transactionName})
+			Expect(err).NotTo(HaveOccurred())
+
+			transaction := &kubemonv1.Transaction{}
+			Expect(c.Get(ctx, transactionName, transaction)).To(Succeed())
+			Expect(transaction.Spec.From).To(Equal("tobi"))
+			Expect(transaction.Spec.Amount).To(Equal(int64(40)))
+			Expect(transaction.Status.Phase).To(Equal(kubemonv1.TransactionCompleted))
+
+			purchase = reconcilePurchase()
+			Expect(purchase.Status.Phase).To(Equal(kubemonv1.PurchasePaid))
+			Expect(ledgerBalance(ctx, c, "default", "tobi")).To(Equal(int64(60)))
+
+			By("delivering the Items into the Inventory of the buyer")
+			purchase = reconcilePurchase()
+			Expect(purchase.Status.Phase).To(Equal(kubemonv1.PurchaseCompleted))
+
+			inventory := &kubemonv1.Inventory{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "tobi", Namespace: "default"}, inventory)).To(Succeed())
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 2}))
+			Expect(inventory.Status.Receipts).To(ConsistOf(string(purchase.UID)))
+
+			By("forgetting the receipt of the delivery")
+			reconcilePurchase()
+			Expect(c.Get(ctx, types.NamespacedName{Name: "tobi", Namespace: "default"}, inventory)).To(Succeed())
+			Expect(inventory.Status.Items).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 2}))
+			Expect(inventory.Status.Receipts).To(BeEmpty())
+		})
+
+		It("should fail the Purchase if its payment does not pay for it", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Purchase{}, &kubemonv1.Shop{}, &kubemonv1.Transaction{}, &kubemonv1.Inventory{}).
+				WithObjects(
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "tobi", Namespace: "default"}},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "buy-potions-uid-payment", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{From: "tobi", To: "ash", Amount: 1},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+					},
+					&kubemonv1.Shop{
+						ObjectMeta: metav1.ObjectMeta{Name: "mart", Namespace: "default"},
+						Spec:       kubemonv1.ShopSpec{Items: []kubemonv1.ShopItem{{Name: "potion", Price: 20, Stock: 5}}},
+					},
+					&kubemonv1.Purchase{
+						ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace, UID: "buy-potions-uid"},
+						Spec:       kubemonv1.PurchaseSpec{Shop: "mart", Buyer: "tobi", Item: "potion", Quantity: 2},
+					},
+				).
+				Build()
+
+			r := &PurchaseReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			for i := 0; i < 2; i++ {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			purchase := &kubemonv1.Purchase{}
+			Expect(c.Get(ctx, name, purchase)).To(Succeed())
+			Expect(purchase.Status.Phase).To(Equal(kubemonv1.PurchaseFailed))
+			Expect(purchase.Status.Message).To(Equal(fmt.Sprintf(PurchaseMessagePaymentMismatch, "buy-potions-uid-payment")))
+
+			By("putting the Items back into stock")
+			shop := &kubemonv1.Shop{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "mart", Namespace: "default"}, shop)).To(Succeed())
+			Expect(shop.Status.Stock).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 5}))
+			Expect(shop.Status.Reservations).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,112 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	defaultRestockInterval = time.Hour
+)
+
+// ShopReconciler reconciles a Shop object
+type ShopReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=shops,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=shops/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=shops/finalizers,verbs=update
+
+func (r *ShopReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var shop kubemonv1.Shop
+	if err := r.Get(ctx, req.NamespacedName, &shop); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find Shop")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if shop.DeletionTimestamp != nil {
+		log.V(1).Info("Shop is marked for deletion, stop reconciling")
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	status := shop.Status.DeepCopy()
+
+	interval := durationOrDefault(shop.Spec.RestockInterval, defaultRestockInterval)
+	if status.LastRestock == nil {
+		status.LastRestock = ptr.To(metav1.Now())
+	} else if time.Since(status.LastRestock.Time) >= interval {
+		log.Info("Restocking Shop")
+		status.LastRestock = ptr.To(metav1.Now())
+		status.Sold = nil
+		status.Reservations = nil
+	}
+	status.Stock = shopStock(&shop.Spec, status.Sold)
+
+	result := ctrl.Result{RequeueAfter: time.Until(status.LastRestock.Add(interval))}
+	if equality.Semantic.DeepEqual(status, &shop.Status) {
+		return result, nil
+	}
+
+	shop.Status = *status
+	if err := r.Status().Update(ctx, &shop); err != nil {
+		log.Error(err, "Could not update status of Shop")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// shopStock returns the number of Items left in a Shop, after the given number of Items were sold since the last restock
+func shopStock(spec *kubemonv1.ShopSpec, sold []kubemonv1.ItemStack) []kubemonv1.ItemStack {
+	stock := make([]kubemonv1.ItemStack, 0, len(spec.Items))
+	for _, item := range spec.Items {
+		left := item.Stock
+		for _, stack := range sold {
+			if stack.Name == item.Name {
+				left -= stack.Quantity
+			}
+		}
+		stock = append(stock, kubemonv1.ItemStack{Name: item.Name, Quantity: max(left, 0)})
+	}
+	return stock
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ShopReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.Shop{}).
+		Complete(r)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var _ = Describe("Shop Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		shop := &kubemonv1.Shop{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind Shop")
+			err := k8sClient.Get(ctx, typeNamespacedName, shop)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &kubemonv1.Shop{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: kubemonv1.ShopSpec{
+						Items: []kubemonv1.ShopItem{{Name: "potion", Price: 20, Stock: 10}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &kubemonv1.Shop{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance Shop")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &ShopReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Restocking the Shop")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, shop)).To(Succeed())
+			Expect(shop.Status.LastRestock).NotTo(BeNil())
+			Expect(shop.Status.Stock).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 10}))
+		})
+	})
+
+	Context("When restocking a Shop", func() {
+		ctx := context.Background()
+		name := types.NamespacedName{Name: "restocked-shop", Namespace: "default"}
+
+		It("should stock a new Shop and refill the stock once the restock interval passed", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Shop{}).
+				WithObjects(&kubemonv1.Shop{
+					ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+					Spec: kubemonv1.ShopSpec{
+						Items:           []kubemonv1.ShopItem{{Name: "potion", Price: 20, Stock: 5}},
+						RestockInterval: &metav1.Duration{Duration: time.Hour},
+					},
+				}).
+				Build()
+
+			r := &ShopReconciler{Client: c, Scheme: c.Scheme()}
+			reconcileShop := func() (*kubemonv1.Shop, time.Duration) {
+				result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: name})
+				Expect(err).NotTo(HaveOccurred())
+
+				shop := &kubemonv1.Shop{}
+				Expect(c.Get(ctx, name, shop)).To(Succeed())
+				return shop, result.RequeueAfter
+			}
+
+			By("stocking the new Shop")
+			shop, requeueAfter := reconcileShop()
+			Expect(shop.Status.Stock).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 5}))
+			Expect(shop.Status.LastRestock).NotTo(BeNil())
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+			By("refilling the stock sold before the last restock")
+			shop.Status.Sold = []kubemonv1.ItemStack{{Name: "potion", Quantity: 3}}
+			shop.Status.Reservations = []types.UID{"sold-purchase-uid"}
+			shop.Status.LastRestock = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(c.Status().Update(ctx, shop)).To(Succeed())
+
+			shop, requeueAfter = reconcileShop()
+			Expect(shop.Status.Stock).To(ConsistOf(kubemonv1.ItemStack{Name: "potion", Quantity: 5}))
+			Expect(shop.Status.Sold).To(BeEmpty())
+			Expect(shop.Status.Reservations).To(BeEmpty())
+			Expect(shop.Status.LastRestock.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+		})
+	})
+})
@@ -0,0 +1,184 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+// TrainerReconciler reconciles a Trainer object
+type TrainerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=catchattempts,verbs=get;list;watch
+
+func (r *TrainerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var trainer kubemonv1.Trainer
+	if err := r.Get(ctx, req.NamespacedName, &trainer); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find Trainer")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if trainer.DeletionTimestamp != nil {
+		log.V(1).Info("Trainer is marked for deletion, stop reconciling")
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	var mons kubemonv1.KubeMonList
+	if err := r.List(ctx, &mons, client.InNamespace(trainer.Namespace), client.MatchingFields{kubeMonOwnerField: trainer.Name}); err != nil {
+		log.Error(err, "Could not list KubeMons of Trainer")
+		return ctrl.Result{}, err
+	}
+
+	status := trainer.Status.DeepCopy()
+	status.KubeMons = int32(len(mons.Items))
+	status.Wins = 0
+	status.Losses = 0
+
+	balance, err := ledgerBalance(ctx, r.Client, trainer.Namespace, trainer.Name)
+	if err != nil {
+		log.Error(err, "Could not list Transactions of Trainer")
+		return ctrl.Result{}, err
+	}
+	status.Balance = balance
+
+	var attempts kubemonv1.CatchAttemptList
+	if err := r.List(ctx, &attempts, client.InNamespace(trainer.Namespace)); err != nil {
+		log.Error(err, "Could not list CatchAttempts of Trainer")
+		return ctrl.Result{}, err
+	}
+	status.Captures = 0
+	for _, attempt := range attempts.Items {
+		if attempt.Spec.Trainer == trainer.Name && attempt.Status.Phase == kubemonv1.CatchAttemptCaught {
+			status.Captures++
+		}
+	}
+
+	owned := make(map[string]bool, len(mons.Items))
+	for _, mon := range mons.Items {
+		owned[mon.Name] = true
+		status.Wins += mon.Status.Wins
+		status.Losses += mon.Status.Losses
+	}
+
+	var foreign []string
+	for _, name := range trainer.Spec.Party {
+		if !owned[name] {
+			foreign = append(foreign, name)
+		}
+	}
+	if len(foreign) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kubemonv1.TrainerConditionPartyValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             "KubeMonsNotOwned",
+			Message:            fmt.Sprintf("KubeMons %s are not owned by the Trainer", strings.Join(foreign, ", ")),
+			ObservedGeneration: trainer.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kubemonv1.TrainerConditionPartyValid,
+			Status:             metav1.ConditionTrue,
+			Reason:             "KubeMonsOwned",
+			Message:            "All KubeMons of the party are owned by the Trainer",
+			ObservedGeneration: trainer.Generation,
+		})
+	}
+
+	if equality.Semantic.DeepEqual(status, &trainer.Status) {
+		return ctrl.Result{}, nil
+	}
+
+	trainer.Status = *status
+	if err := r.Status().Update(ctx, &trainer); err != nil {
+		log.Error(err, "Could not update status of Trainer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// trainersForKubeMon enqueues the Trainer owning a KubeMon, as well as Trainers which owned it before
+func (r *TrainerReconciler) trainersForKubeMon(ctx context.Context, mon client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	if owner := mon.(*kubemonv1.KubeMon).Spec.Owner; owner != "" {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mon.GetNamespace(), Name: owner}})
+	}
+
+	for _, ref := range mon.GetOwnerReferences() {
+		if ref.Kind == "Trainer" && ref.APIVersion == kubemonv1.GroupVersion.String() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: mon.GetNamespace(), Name: ref.Name}})
+		}
+	}
+	return requests
+}
+
+// trainersForTransaction enqueues the Trainers whose balance is changed by a Transaction
+func (r *TrainerReconciler) trainersForTransaction(ctx context.Context, transaction client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, name := range []string{transaction.(*kubemonv1.Transaction).Spec.From, transaction.(*kubemonv1.Transaction).Spec.To} {
+		if name != "" {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: transaction.GetNamespace(), Name: name}})
+		}
+	}
+	return requests
+}
+
+// trainerForCatchAttempt enqueues the Trainer throwing the Ball of a CatchAttempt
+func (r *TrainerReconciler) trainerForCatchAttempt(ctx context.Context, attempt client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: attempt.GetNamespace(),
+		Name:      attempt.(*kubemonv1.CatchAttempt).Spec.Trainer,
+	}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TrainerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.Trainer{}).
+		Watches(&kubemonv1.KubeMon{}, handler.EnqueueRequestsFromMapFunc(r.trainersForKubeMon)).
+		Watches(&kubemonv1.Transaction{}, handler.EnqueueRequestsFromMapFunc(r.trainersForTransaction)).
+		Watches(&kubemonv1.CatchAttempt{}, handler.EnqueueRequestsFromMapFunc(r.trainerForCatchAttempt)).
+		Complete(r)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var _ = Describe("Trainer Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "ash"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		var c client.Client
+
+		BeforeEach(func() {
+			By("creating the Trainer with a KubeMon, Transactions and a CatchAttempt")
+			// The Trainer controller lists KubeMons by their owner, which needs the field index of the cache
+			c = fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Trainer{}, &kubemonv1.KubeMon{}).
+				WithIndex(&kubemonv1.KubeMon{}, kubeMonOwnerField, kubeMonOwner).
+				WithObjects(
+					&kubemonv1.Species{
+						ObjectMeta: metav1.ObjectMeta{Name: "trainer-species"},
+						Spec:       kubemonv1.SpeciesSpec{BaseHP: 45, BaseAttack: 49, BaseDefense: 49, BaseSpeed: 45},
+					},
+					&kubemonv1.Trainer{
+						ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default", UID: "ash-uid"},
+						Spec:       kubemonv1.TrainerSpec{Party: []string{"ash-mon", "tobi-mon"}},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash-mon", Namespace: "default"},
+						Spec:       kubemonv1.KubeMonSpec{Species: "trainer-species", Owner: resourceName, Strength: 1},
+						Status:     kubemonv1.KubeMonStatus{Wins: 3, Losses: 1},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: "tobi-mon", Namespace: "default"},
+						Spec:       kubemonv1.KubeMonSpec{Species: "trainer-species", Owner: "tobi", Strength: 1},
+						Status:     kubemonv1.KubeMonStatus{Wins: 5},
+					},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash-payout", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{To: resourceName, Amount: 100, Fight: "fight"},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+					},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash-purchase", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{From: resourceName, Amount: 30},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+					},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash-failed", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{From: resourceName, Amount: 500},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionFailed},
+					},
+					&kubemonv1.CatchAttempt{
+						ObjectMeta: metav1.ObjectMeta{Name: "ash-catch", Namespace: "default"},
+						Spec:       kubemonv1.CatchAttemptSpec{Trainer: resourceName, KubeMon: "ash-mon"},
+						Status:     kubemonv1.CatchAttemptStatus{Phase: kubemonv1.CatchAttemptCaught},
+					},
+				).
+				Build()
+		})
+
+		It("should make the Trainer an owner of its KubeMons", func() {
+			By("Reconciling the KubeMon owned by the Trainer")
+			_, err := (&KubeMonReconciler{
+				Client:   c,
+				Scheme:   c.Scheme(),
+				Recorder: &record.FakeRecorder{},
+			}).Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "ash-mon", Namespace: "default"}})
+			Expect(err).NotTo(HaveOccurred())
+
+			mon := &kubemonv1.KubeMon{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "ash-mon", Namespace: "default"}, mon)).To(Succeed())
+			Expect(mon.OwnerReferences).To(ConsistOf(HaveField("UID", types.UID("ash-uid"))))
+			Expect(meta.IsStatusConditionTrue(mon.Status.Conditions, kubemonv1.KubeMonConditionTrainerResolved)).To(BeTrue())
+		})
+
+		It("should sum up the KubeMons, Fights, balance and captures of the Trainer", func() {
+			By("Reconciling the Trainer")
+			controllerReconciler := &TrainerReconciler{
+				Client: c,
+				Scheme: c.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			trainer := &kubemonv1.Trainer{}
+			Expect(c.Get(ctx, typeNamespacedName, trainer)).To(Succeed())
+			Expect(trainer.Status.KubeMons).To(Equal(int32(1)))
+			Expect(trainer.Status.Wins).To(Equal(int32(3)))
+			Expect(trainer.Status.Losses).To(Equal(int32(1)))
+			Expect(trainer.Status.Balance).To(Equal(int64(70)))
+			Expect(trainer.Status.Captures).To(Equal(int32(1)))
+
+			By("Reporting the KubeMon of another Trainer in the party")
+			condition := meta.FindStatusCondition(trainer.Status.Conditions, kubemonv1.TrainerConditionPartyValid)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("tobi-mon"))
+		})
+	})
+})
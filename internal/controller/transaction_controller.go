@@ -0,0 +1,265 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+var (
+	TransactionMessageCompleted         = "Transferred %d coins"
+	TransactionMessageTrainerNotFound   = "Trainer %s does not exist"
+	TransactionMessageSameTrainer       = "Coins cannot be transferred to the paying Trainer"
+	TransactionMessageInsufficientFunds = "Trainer %s has %d coins, but %d are needed"
+	TransactionMessageNoFight           = "Coins can only be paid out for a Fight"
+	TransactionMessageInvalidPayout     = "Fight %s does not pay out %d coins to Trainer %s"
+	TransactionMessageAlreadyPaidOut    = "Fight %s has already been paid out"
+)
+
+// TransactionReconciler reconciles a Transaction object.
+// Transactions are completed one after another by a single worker, and balances are read through APIReader,
+// so that a Trainer can never spend the same coins twice.
+type TransactionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// APIReader reads directly from the API server, bypassing the cache of the Client
+	APIReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=transactions/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=trainers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=fights,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kubemon.memetoasty.github.com,resources=kubemons,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get
+
+func (r *TransactionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	var transaction kubemonv1.Transaction
+	if err := r.APIReader.Get(ctx, req.NamespacedName, &transaction); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			log.Info("Could not find Transaction")
+		}
+
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if transaction.DeletionTimestamp != nil {
+		log.V(1).Info("Transaction is marked for deletion, stop reconciling")
+		if err := r.releaseLedger(ctx, &transaction); err != nil {
+			log.Error(err, "Could not remove ledger finalizer from Transaction")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: false}, nil
+	}
+
+	if transaction.Status.Phase != "" && transaction.Status.Phase != kubemonv1.TransactionPending {
+		return ctrl.Result{}, nil
+	}
+
+	message, err := r.validate(ctx, &transaction)
+	if err != nil {
+		log.Error(err, "Could not validate Transaction")
+		return ctrl.Result{}, err
+	}
+
+	if message != "" {
+		transaction.Status.Phase = kubemonv1.TransactionFailed
+		transaction.Status.Message = message
+	} else {
+		if controllerutil.AddFinalizer(&transaction, kubemonv1.LedgerFinalizer) {
+			if err := r.Update(ctx, &transaction); err != nil {
+				log.Error(err, "Could not add ledger finalizer to Transaction")
+				return ctrl.Result{}, err
+			}
+		}
+
+		transaction.Status.Phase = kubemonv1.TransactionCompleted
+		transaction.Status.Message = fmt.Sprintf(TransactionMessageCompleted, transaction.Spec.Amount)
+		transaction.Status.CompletedAt = ptr.To(metav1.Now())
+	}
+
+	if err := r.Status().Update(ctx, &transaction); err != nil {
+		log.Error(err, "Could not update status of Transaction")
+		return ctrl.Result{}, err
+	}
+
+	log.Info("Processed Transaction", "Phase", transaction.Status.Phase, "Message", transaction.Status.Message)
+	return ctrl.Result{}, nil
+}
+
+// releaseLedger removes the ledger finalizer from a Transaction once its namespace is being deleted,
+// so the Transaction does not keep the namespace from terminating
+func (r *TransactionReconciler) releaseLedger(ctx context.Context, transaction *kubemonv1.Transaction) error {
+	if !controllerutil.ContainsFinalizer(transaction, kubemonv1.LedgerFinalizer) {
+		return nil
+	}
+
+	var namespace corev1.Namespace
+	err := r.APIReader.Get(ctx, types.NamespacedName{Name: transaction.Namespace}, &namespace)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil && namespace.DeletionTimestamp == nil {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(transaction, kubemonv1.LedgerFinalizer)
+	return r.Update(ctx, transaction)
+}
+
+// validate checks whether the Transaction can be completed.
+// It returns the reason for rejecting the Transaction, or an empty string if it is valid.
+func (r *TransactionReconciler) validate(ctx context.Context, transaction *kubemonv1.Transaction) (string, error) {
+	spec := transaction.Spec
+
+	if spec.From == spec.To {
+		return TransactionMessageSameTrainer, nil
+	}
+
+	for _, name := range []string{spec.From, spec.To} {
+		if name == "" {
+			continue
+		}
+		var trainer kubemonv1.Trainer
+		err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: transaction.Namespace, Name: name}, &trainer)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf(TransactionMessageTrainerNotFound, name), nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+
+	if spec.From == "" {
+		return r.validatePayout(ctx, transaction)
+	}
+
+	balance, err := ledgerBalance(ctx, r.APIReader, transaction.Namespace, spec.From)
+	if err != nil {
+		return "", err
+	}
+	if balance < spec.Amount {
+		return fmt.Sprintf(TransactionMessageInsufficientFunds, spec.From, balance, spec.Amount), nil
+	}
+	return "", nil
+}
+
+// validatePayout checks that the Transaction pays out the reward of a finished Fight to the Trainer of its winner
+// in the namespace of the Transaction, and that the Fight with the UID of the Transaction has not been paid out before
+func (r *TransactionReconciler) validatePayout(ctx context.Context, transaction *kubemonv1.Transaction) (string, error) {
+	spec := transaction.Spec
+	if spec.Fight == "" || spec.To == "" {
+		return TransactionMessageNoFight, nil
+	}
+
+	invalid := fmt.Sprintf(TransactionMessageInvalidPayout, spec.Fight, spec.Amount, spec.To)
+
+	var fight kubemonv1.Fight
+	fightName := types.NamespacedName{Namespace: namespaceOrDefault(spec.FightNamespace, transaction.Namespace), Name: spec.Fight}
+	err := r.APIReader.Get(ctx, fightName, &fight)
+	if apierrors.IsNotFound(err) {
+		return invalid, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	// Fights are identified by their UID, as a Fight may be recreated under the name of one which has been paid out already
+	if spec.FightUID == "" || spec.FightUID != fight.UID {
+		return invalid, nil
+	}
+
+	if fight.Status.Phase != kubemonv1.FightFinished || fight.Status.Winner == nil || fight.Status.Loser == nil {
+		return invalid, nil
+	}
+
+	var winner, loser kubemonv1.KubeMon
+	if err := r.APIReader.Get(ctx, kubeMonKey(fight.Status.Winner), &winner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return invalid, nil
+		}
+		return "", err
+	}
+	if err := r.APIReader.Get(ctx, kubeMonKey(fight.Status.Loser), &loser); err != nil {
+		if apierrors.IsNotFound(err) {
+			return invalid, nil
+		}
+		return "", err
+	}
+
+	if winner.Namespace != transaction.Namespace || winner.Spec.Owner != spec.To || loser.Status.Level == nil || kubemon.FightPayout(*loser.Status.Level) != spec.Amount {
+		return invalid, nil
+	}
+
+	var list kubemonv1.TransactionList
+	if err := r.APIReader.List(ctx, &list, client.InNamespace(transaction.Namespace)); err != nil {
+		return "", err
+	}
+	for _, other := range list.Items {
+		if other.Spec.From == "" && other.Spec.FightUID == spec.FightUID && other.Status.Phase == kubemonv1.TransactionCompleted {
+			return fmt.Sprintf(TransactionMessageAlreadyPaidOut, spec.Fight), nil
+		}
+	}
+	return "", nil
+}
+
+// ledgerBalance sums up the completed Transactions of a Trainer
+func ledgerBalance(ctx context.Context, reader client.Reader, namespace, trainer string) (int64, error) {
+	var list kubemonv1.TransactionList
+	if err := reader.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+		return 0, err
+	}
+
+	var balance int64
+	for _, transaction := range list.Items {
+		if transaction.Status.Phase != kubemonv1.TransactionCompleted {
+			continue
+		}
+		if transaction.Spec.To == trainer {
+			balance += transaction.Spec.Amount
+		}
+		if transaction.Spec.From == trainer {
+			balance -= transaction.Spec.Amount
+		}
+	}
+	return balance, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TransactionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubemonv1.Transaction{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		Complete(r)
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	kubemon "github.com/memeToasty/kubemon/internal/kubemon"
+)
+
+var _ = Describe("Transaction Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-resource"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default", // TODO(user):Modify as needed
+		}
+		transaction := &kubemonv1.Transaction{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind Transaction")
+			err := k8sClient.Get(ctx, typeNamespacedName, transaction)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &kubemonv1.Transaction{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: kubemonv1.TransactionSpec{
+						To:     "missing-trainer",
+						Amount: 10,
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			// TODO(user): Cleanup logic after each test, like removing the resource instance.
+			resource := &kubemonv1.Transaction{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance Transaction")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &TransactionReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				APIReader: k8sClient,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Failing the Transaction to a missing Trainer")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, transaction)).To(Succeed())
+			Expect(transaction.Status.Phase).To(Equal(kubemonv1.TransactionFailed))
+		})
+	})
+
+	Context("When transferring coins between Trainers", func() {
+		ctx := context.Background()
+
+		It("should complete a covered Transaction and reject one exceeding the balance", func() {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Transaction{}).
+				WithObjects(
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "brock", Namespace: "default"}},
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "misty", Namespace: "default"}},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "brock-payout", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{To: "brock", Amount: 50, Fight: "won-fight"},
+						Status:     kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+					},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "brock-to-misty", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{From: "brock", To: "misty", Amount: 30},
+					},
+					&kubemonv1.Transaction{
+						ObjectMeta: metav1.ObjectMeta{Name: "brock-to-misty-again", Namespace: "default"},
+						Spec:       kubemonv1.TransactionSpec{From: "brock", To: "misty", Amount: 30},
+					},
+				).
+				Build()
+
+			r := &TransactionReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			reconcileTransaction := func(name string) *kubemonv1.Transaction {
+				key := types.NamespacedName{Name: name, Namespace: "default"}
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				transaction := &kubemonv1.Transaction{}
+				Expect(c.Get(ctx, key, transaction)).To(Succeed())
+				return transaction
+			}
+
+			By("completing the Transaction covered by the balance")
+			transaction := reconcileTransaction("brock-to-misty")
+			Expect(transaction.Status.Phase).To(Equal(kubemonv1.TransactionCompleted))
+			Expect(transaction.Status.CompletedAt).NotTo(BeNil())
+			Expect(transaction.Finalizers).To(ContainElement(kubemonv1.LedgerFinalizer))
+
+			Expect(ledgerBalance(ctx, c, "default", "brock")).To(Equal(int64(20)))
+			Expect(ledgerBalance(ctx, c, "default", "misty")).To(Equal(int64(30)))
+
+			By("failing the Transaction exceeding the balance")
+			transaction = reconcileTransaction("brock-to-misty-again")
+			Expect(transaction.Status.Phase).To(Equal(kubemonv1.TransactionFailed))
+			Expect(transaction.Status.Message).To(Equal(fmt.Sprintf(TransactionMessageInsufficientFunds, "brock", 20, 30)))
+			Expect(transaction.Finalizers).To(BeEmpty())
+
+			Expect(ledgerBalance(ctx, c, "default", "brock")).To(Equal(int64(20)))
+		})
+	})
+
+	Context("When paying out a Fight", func() {
+		ctx := context.Background()
+
+		It("should pay out a Fight recreated under the name of a Fight paid out before, but only once", func() {
+			payout := func(name string, uid types.UID, phase kubemonv1.TransactionPhase) *kubemonv1.Transaction {
+				return &kubemonv1.Transaction{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+					Spec:       kubemonv1.TransactionSpec{To: "brock", Amount: kubemon.FightPayout(5), Fight: "rematch", FightUID: uid},
+					Status:     kubemonv1.TransactionStatus{Phase: phase},
+				}
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Transaction{}).
+				WithObjects(
+					&kubemonv1.Trainer{ObjectMeta: metav1.ObjectMeta{Name: "brock", Namespace: "default"}},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: "onix", Namespace: "default"},
+						Spec:       kubemonv1.KubeMonSpec{Species: "rock", Owner: "brock", Strength: 1},
+					},
+					&kubemonv1.KubeMon{
+						ObjectMeta: metav1.ObjectMeta{Name: "geodude", Namespace: "default"},
+						Spec:       kubemonv1.KubeMonSpec{Species: "rock", Strength: 1},
+						Status:     kubemonv1.KubeMonStatus{Level: ptr.To(int32(5))},
+					},
+					&kubemonv1.Fight{
+						ObjectMeta: metav1.ObjectMeta{Name: "rematch", Namespace: "default", UID: "rematch-2"},
+						Status: kubemonv1.FightStatus{
+							Phase:  kubemonv1.FightFinished,
+							Winner: &kubemonv1.KubeMonReference{Name: "onix", Namespace: "default"},
+							Loser:  &kubemonv1.KubeMonReference{Name: "geodude", Namespace: "default"},
+						},
+					},
+					payout("payout-rematch-1", "rematch-1", kubemonv1.TransactionCompleted),
+					payout("payout-rematch-2", "rematch-2", ""),
+					payout("payout-rematch-2-again", "rematch-2", ""),
+					payout("payout-rematch-unknown", "", ""),
+				).
+				Build()
+
+			r := &TransactionReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			reconcileTransaction := func(name string) *kubemonv1.Transaction {
+				key := types.NamespacedName{Name: name, Namespace: "default"}
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				transaction := &kubemonv1.Transaction{}
+				Expect(c.Get(ctx, key, transaction)).To(Succeed())
+				return transaction
+			}
+
+			By("completing the payout of the recreated Fight")
+			Expect(reconcileTransaction("payout-rematch-2").Status.Phase).To(Equal(kubemonv1.TransactionCompleted))
+
+			By("rejecting a second payout of the recreated Fight")
+			transaction := reconcileTransaction("payout-rematch-2-again")
+			Expect(transaction.Status.Phase).To(Equal(kubemonv1.TransactionFailed))
+			Expect(transaction.Status.Message).To(Equal(fmt.Sprintf(TransactionMessageAlreadyPaidOut, "rematch")))
+
+			By("rejecting a payout without the UID of the Fight")
+			Expect(reconcileTransaction("payout-rematch-unknown").Status.Phase).To(Equal(kubemonv1.TransactionFailed))
+
+			Expect(ledgerBalance(ctx, c, "default", "brock")).To(Equal(2 * kubemon.FightPayout(5)))
+		})
+	})
+
+	Context("When the namespace of a Transaction is deleted", func() {
+		ctx := context.Background()
+
+		It("should only remove the ledger finalizer while the namespace is terminating", func() {
+			deleted := func(namespace string) *kubemonv1.Transaction {
+				return &kubemonv1.Transaction{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "ledger-entry",
+						Namespace:         namespace,
+						DeletionTimestamp: ptr.To(metav1.Now()),
+						Finalizers:        []string{kubemonv1.LedgerFinalizer},
+					},
+					Spec:   kubemonv1.TransactionSpec{From: "brock", To: "misty", Amount: 30},
+					Status: kubemonv1.TransactionStatus{Phase: kubemonv1.TransactionCompleted},
+				}
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithStatusSubresource(&kubemonv1.Transaction{}).
+				WithObjects(
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "active"}},
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+						Name:              "terminating",
+						DeletionTimestamp: ptr.To(metav1.Now()),
+						Finalizers:        []string{"kubernetes"},
+					}},
+					deleted("active"),
+					deleted("terminating"),
+				).
+				Build()
+
+			r := &TransactionReconciler{Client: c, Scheme: c.Scheme(), APIReader: c}
+			for _, namespace := range []string{"active", "terminating"} {
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "ledger-entry", Namespace: namespace}})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("keeping the Transaction in an active namespace")
+			transaction := &kubemonv1.Transaction{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "ledger-entry", Namespace: "active"}, transaction)).To(Succeed())
+			Expect(transaction.Finalizers).To(ContainElement(kubemonv1.LedgerFinalizer))
+
+			By("releasing the Transaction in a terminating namespace")
+			err := c.Get(ctx, types.NamespacedName{Name: "ledger-entry", Namespace: "terminating"}, transaction)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package kubemon
+
+import (
+	"math/rand"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	// poisonDamageRate is the inverse share of the maximum HP a poisoned KubeMon loses each turn
+	poisonDamageRate = 8
+	// burnDamageRate is the inverse share of the maximum HP a burned KubeMon loses each turn
+	burnDamageRate = 16
+	// paralysisSkipRate is the inverse chance of a paralyzed KubeMon skipping its turn
+	paralysisSkipRate = 4
+	// maxSleepTurns is the most turns a KubeMon keeps sleeping, the least is 1
+	maxSleepTurns = 3
+)
+
+// ailmentInflicted describes a KubeMon getting an ailment, following its name
+var ailmentInflicted = map[kubemonv1.Ailment]string{
+	kubemonv1.AilmentPoison:    "was poisoned",
+	kubemonv1.AilmentBurn:      "was burned",
+	kubemonv1.AilmentSleep:     "fell asleep",
+	kubemonv1.AilmentParalysis: "was paralyzed",
+}
+
+// Ailment returns the status ailment of the KubeMon, or an empty string if it is healthy
+func (k *KubeMon) Ailment() kubemonv1.Ailment {
+	if k.apiKubeMon.Status.Ailment == nil {
+		return ""
+	}
+	return k.apiKubeMon.Status.Ailment.Type
+}
+
+// inflictAilment gives the KubeMon the ailment, unless it already suffers from one or has fainted.
+// Sleeping KubeMons sleep for a random number of turns. The status is not persisted.
+func (k *KubeMon) inflictAilment(ailment kubemonv1.Ailment, rng *rand.Rand) bool {
+	if k.apiKubeMon.Status.Ailment != nil || k.IsDead() {
+		return false
+	}
+	k.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: ailment}
+	if ailment == kubemonv1.AilmentSleep {
+		k.apiKubeMon.Status.Ailment.SleepTurns = 1 + rng.Int31n(maxSleepTurns)
+	}
+	return true
+}
+
+// CureAilment cures the status ailment of the KubeMon
+func (k *KubeMon) CureAilment() error {
+	if k.apiKubeMon.Status.Ailment == nil {
+		return nil
+	}
+	k.apiKubeMon.Status.Ailment = nil
+	return k.updateStatus()
+}
+
+// CheckAilment decides whether the ailment of the KubeMon lets it act this turn.
+// Sleeping KubeMons skip their turns until they wake up, paralyzed KubeMons skip a quarter of their turns.
+// It reports whether the KubeMon can act and whether it just woke up.
+func (k *KubeMon) CheckAilment(rng *rand.Rand) (bool, bool, error) {
+	switch k.Ailment() {
+	case kubemonv1.AilmentSleep:
+		if k.apiKubeMon.Status.Ailment.SleepTurns > 0 {
+			k.apiKubeMon.Status.Ailment.SleepTurns--
+			return false, false, k.updateStatus()
+		}
+		return true, true, k.CureAilment()
+	case kubemonv1.AilmentParalysis:
+		return rng.Intn(paralysisSkipRate) != 0, false, nil
+	}
+	return true, false, nil
+}
+
+// AilmentDamage hurts a poisoned or burned KubeMon at the end of a turn and returns the damage dealt
+func (k *KubeMon) AilmentDamage() (int32, error) {
+	var damage int32
+	switch k.Ailment() {
+	case kubemonv1.AilmentPoison:
+		damage = max(k.MaxHP()/poisonDamageRate, 1)
+	case kubemonv1.AilmentBurn:
+		damage = max(k.MaxHP()/burnDamageRate, 1)
+	default:
+		return 0, nil
+	}
+	return damage, k.GetDamage(damage)
+}
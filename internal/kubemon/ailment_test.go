@@ -0,0 +1,174 @@
+package kubemon
+
+import (
+	"math/rand"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+func TestAilmentDamage(t *testing.T) {
+	tests := []struct {
+		ailment kubemonv1.Ailment
+		// wantRate is the inverse share of the maximum HP lost, 0 for no damage
+		wantRate int32
+	}{
+		{ailment: kubemonv1.AilmentPoison, wantRate: poisonDamageRate},
+		{ailment: kubemonv1.AilmentBurn, wantRate: burnDamageRate},
+		{ailment: kubemonv1.AilmentSleep},
+		{ailment: kubemonv1.AilmentParalysis},
+		{ailment: ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.ailment), func(t *testing.T) {
+			mon := newTestKubeMon(t, 50)
+			if tt.ailment != "" {
+				mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: tt.ailment}
+			}
+			hp := mon.HP()
+
+			damage, err := mon.AilmentDamage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want int32
+			if tt.wantRate > 0 {
+				want = mon.MaxHP() / tt.wantRate
+			}
+			if damage != want || mon.HP() != hp-want {
+				t.Errorf("AilmentDamage() = %d, HP %d, want %d, HP %d", damage, mon.HP(), want, hp-want)
+			}
+		})
+	}
+}
+
+func TestAilmentDamageFaints(t *testing.T) {
+	mon := newTestKubeMon(t, 50)
+	mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentPoison}
+	if err := mon.SetHealth(1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mon.AilmentDamage(); err != nil {
+		t.Fatal(err)
+	}
+	if !mon.IsDead() || mon.Ailment() != "" {
+		t.Errorf("KubeMon has %d HP and ailment %q, want it fainted without ailment", mon.HP(), mon.Ailment())
+	}
+}
+
+func TestCheckAilmentSleep(t *testing.T) {
+	mon := newTestKubeMon(t, 10)
+	mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentSleep, SleepTurns: 2}
+	rng := rand.New(rand.NewSource(1))
+
+	for turn, want := range []struct{ canAct, wokeUp bool }{{false, false}, {false, false}, {true, true}, {true, false}} {
+		canAct, wokeUp, err := mon.CheckAilment(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canAct != want.canAct || wokeUp != want.wokeUp {
+			t.Errorf("turn %d: CheckAilment() = %t, %t, want %t, %t", turn, canAct, wokeUp, want.canAct, want.wokeUp)
+		}
+	}
+	if mon.Ailment() != "" {
+		t.Errorf("KubeMon still suffers from %q after waking up", mon.Ailment())
+	}
+}
+
+func TestCheckAilmentParalysis(t *testing.T) {
+	mon := newTestKubeMon(t, 10)
+	speed := mon.Speed()
+	mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentParalysis}
+	if mon.Speed() != speed/2 {
+		t.Errorf("Speed() = %d, want %d", mon.Speed(), speed/2)
+	}
+
+	rng, expected := rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1))
+	var skipped int
+	for turn := 0; turn < 100; turn++ {
+		canAct, wokeUp, err := mon.CheckAilment(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := expected.Intn(paralysisSkipRate) != 0; canAct != want || wokeUp {
+			t.Errorf("turn %d: CheckAilment() = %t, %t, want %t, false", turn, canAct, wokeUp, want)
+		}
+		if !canAct {
+			skipped++
+		}
+	}
+	if skipped == 0 || skipped == 100 {
+		t.Errorf("a paralyzed KubeMon skipped %d of 100 turns", skipped)
+	}
+	if mon.Ailment() != kubemonv1.AilmentParalysis {
+		t.Errorf("paralysis wore off")
+	}
+}
+
+func TestInflictAilment(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	mon := newTestKubeMon(t, 10)
+	if !mon.inflictAilment(kubemonv1.AilmentSleep, rng) {
+		t.Fatal("could not put a healthy KubeMon to sleep")
+	}
+	if turns := mon.apiKubeMon.Status.Ailment.SleepTurns; turns < 1 || turns > maxSleepTurns {
+		t.Errorf("KubeMon sleeps for %d turns, want 1 to %d", turns, maxSleepTurns)
+	}
+	if mon.inflictAilment(kubemonv1.AilmentPoison, rng) || mon.Ailment() != kubemonv1.AilmentSleep {
+		t.Errorf("second ailment replaced the first, now %q", mon.Ailment())
+	}
+
+	fainted := newTestKubeMon(t, 10)
+	if err := fainted.SetHealth(0); err != nil {
+		t.Fatal(err)
+	}
+	if fainted.inflictAilment(kubemonv1.AilmentPoison, rng) {
+		t.Error("poisoned a fainted KubeMon")
+	}
+}
+
+func TestCureAilment(t *testing.T) {
+	cure := func(cures ...kubemonv1.Ailment) *kubemonv1.Item {
+		return &kubemonv1.Item{
+			ObjectMeta: metav1.ObjectMeta{Name: "cure"},
+			Spec:       kubemonv1.ItemSpec{Category: kubemonv1.ItemCategoryCure, Cures: cures},
+		}
+	}
+	tests := []struct {
+		name      string
+		ailment   kubemonv1.Ailment
+		item      *kubemonv1.Item
+		wantCured bool
+	}{
+		{name: "cure for all ailments", ailment: kubemonv1.AilmentBurn, item: cure(), wantCured: true},
+		{name: "matching cure", ailment: kubemonv1.AilmentPoison, item: cure(kubemonv1.AilmentPoison), wantCured: true},
+		{name: "other cure", ailment: kubemonv1.AilmentPoison, item: cure(kubemonv1.AilmentSleep)},
+		{name: "no ailment", item: cure()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := newTestKubeMon(t, 10)
+			if tt.ailment != "" {
+				mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: tt.ailment}
+			}
+
+			err := mon.UseItem(tt.item)
+			if tt.wantCured && (err != nil || mon.Ailment() != "") {
+				t.Errorf("UseItem() = %v, ailment %q, want it cured", err, mon.Ailment())
+			}
+			if !tt.wantCured && err != ErrItemNoEffect {
+				t.Errorf("UseItem() = %v, want %v", err, ErrItemNoEffect)
+			}
+		})
+	}
+
+	mon := newTestKubeMon(t, 10)
+	mon.apiKubeMon.Status.Ailment = &kubemonv1.KubeMonAilment{Type: kubemonv1.AilmentParalysis}
+	if err := mon.CureAilment(); err != nil || mon.Ailment() != "" {
+		t.Errorf("CureAilment() = %v, ailment %q, want it cured", err, mon.Ailment())
+	}
+}
@@ -0,0 +1,82 @@
+package kubemon
+
+import (
+	"fmt"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	ReasonFighting    = "Fighting"
+	ReasonNotFighting = "NotFighting"
+)
+
+// CurrentFight returns the Fight the KubeMon is taking part in, or nil
+func (k *KubeMon) CurrentFight() *kubemonv1.FightReference {
+	return k.apiKubeMon.Status.CurrentFight
+}
+
+// JoinFight claims the KubeMon for the Fight, replacing any previous claim.
+// The claim is written with the resourceVersion the KubeMon was read with, so only one of several concurrent claims succeeds.
+func (k *KubeMon) JoinFight(fight kubemonv1.FightReference) error {
+	if current := k.CurrentFight(); current != nil && *current == fight {
+		return nil
+	}
+
+	k.apiKubeMon.Status.CurrentFight = &fight
+	k.apiKubeMon.Status.FightTurn = nil
+	k.updateCondition(kubemonv1.KubeMonConditionInBattle, metav1.ConditionTrue, ReasonFighting, fmt.Sprintf("Fighting in Fight %s", fight))
+	return k.updateStatus()
+}
+
+// LeaveFight releases the claim of the Fight on the KubeMon. It does nothing if the KubeMon is not fighting in the Fight.
+func (k *KubeMon) LeaveFight(fight kubemonv1.FightReference) error {
+	if current := k.CurrentFight(); current == nil || *current != fight {
+		return nil
+	}
+
+	k.apiKubeMon.Status.CurrentFight = nil
+	k.apiKubeMon.Status.FightTurn = nil
+	k.updateCondition(kubemonv1.KubeMonConditionInBattle, metav1.ConditionFalse, ReasonNotFighting, fmt.Sprintf("Left Fight %s", fight))
+	return k.updateStatus()
+}
+
+// FightState returns the state of the KubeMon recorded in the outcome of a turn of a Fight
+func (k *KubeMon) FightState() kubemonv1.FightKubeMonState {
+	status := k.apiKubeMon.Status.DeepCopy()
+	return kubemonv1.FightKubeMonState{
+		KubeMonReference: kubemonv1.KubeMonReference{Name: k.Name(), Namespace: k.Namespace()},
+		HP:               k.HP(),
+		Boosts:           status.Boosts,
+		Moves:            status.Moves,
+		Ailment:          status.Ailment,
+	}
+}
+
+// AwaitsFightTurn reports whether a turn of its current Fight has not been applied to the KubeMon yet
+func (k *KubeMon) AwaitsFightTurn(fight kubemonv1.FightReference, turn int32) bool {
+	if current := k.CurrentFight(); current == nil || *current != fight {
+		return false
+	}
+	seen := k.apiKubeMon.Status.FightTurn
+	return seen == nil || *seen < turn
+}
+
+// ApplyFightTurn sets the KubeMon to the state it had after a turn of its current Fight.
+// Turns of other Fights and turns the KubeMon has already seen are skipped.
+func (k *KubeMon) ApplyFightTurn(fight kubemonv1.FightReference, turn int32, state kubemonv1.FightKubeMonState) error {
+	if !k.AwaitsFightTurn(fight, turn) {
+		return nil
+	}
+
+	status := &k.apiKubeMon.Status
+	status.Boosts = state.Boosts
+	k.recalculateStats()
+	status.HP = ptr.To(min(state.HP, k.MaxHP()))
+	status.Moves = state.Moves
+	status.Ailment = state.Ailment
+	status.FightTurn = ptr.To(turn)
+	return k.updateStatus()
+}
@@ -0,0 +1,52 @@
+package kubemon
+
+import (
+	"testing"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+func TestApplyFightTurn(t *testing.T) {
+	fight := kubemonv1.FightReference{Namespace: "default", Name: "fight-sample"}
+	mon := newTestKubeMon(t, 50)
+	if err := mon.JoinFight(fight); err != nil {
+		t.Fatal(err)
+	}
+
+	sim := mon.Simulate()
+	if err := sim.AddHealth(-10); err != nil {
+		t.Fatal(err)
+	}
+	if sim.HP() == mon.HP() {
+		t.Fatalf("HP of simulated KubeMon = %d, want it to differ from the KubeMon", sim.HP())
+	}
+	state := sim.FightState()
+
+	if !mon.AwaitsFightTurn(fight, 0) {
+		t.Fatal("AwaitsFightTurn(0) = false before the turn was applied")
+	}
+	if err := mon.ApplyFightTurn(fight, 0, state); err != nil {
+		t.Fatal(err)
+	}
+	if mon.HP() != state.HP {
+		t.Errorf("HP = %d after applying the turn, want %d", mon.HP(), state.HP)
+	}
+	if mon.AwaitsFightTurn(fight, 0) {
+		t.Error("AwaitsFightTurn(0) = true after the turn was applied")
+	}
+
+	// Turns already seen are skipped
+	hp := mon.HP()
+	state.HP = 1
+	if err := mon.ApplyFightTurn(fight, 0, state); err != nil {
+		t.Fatal(err)
+	}
+	if mon.HP() != hp {
+		t.Errorf("HP = %d after applying a seen turn again, want %d", mon.HP(), hp)
+	}
+
+	other := kubemonv1.FightReference{Namespace: "default", Name: "other"}
+	if mon.AwaitsFightTurn(other, 1) {
+		t.Error("AwaitsFightTurn() = true for a Fight the KubeMon is not fighting in")
+	}
+}
@@ -0,0 +1,63 @@
+package kubemon
+
+import (
+	"errors"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	ErrNotWild = errors.New("kubeMon already has an owner")
+)
+
+const (
+	defaultCatchRate  int32 = 45
+	defaultCatchBonus int32 = 100
+)
+
+// CatchChance returns the chance in percent of catching a KubeMon with the given HP.
+// The chance grows the less HP the KubeMon has left, and scales with the catch rate of its Species and the catch bonus of the Ball.
+func CatchChance(maxHP, hp, catchRate, catchBonus int32) int32 {
+	if maxHP < 1 {
+		return 0
+	}
+	hp = max(min(hp, maxHP), 0)
+
+	chance := int64(3*maxHP-2*hp) * int64(catchRate) * int64(catchBonus) / (int64(3*maxHP) * 255)
+	return int32(min(chance, 100))
+}
+
+// CatchChance returns the chance in percent of catching the KubeMon with the Ball
+func (k *KubeMon) CatchChance(ball *kubemonv1.Item) int32 {
+	catchRate := k.species.Spec.CatchRate
+	if catchRate == 0 {
+		catchRate = defaultCatchRate
+	}
+	catchBonus := ball.Spec.CatchBonus
+	if catchBonus == 0 {
+		catchBonus = defaultCatchBonus
+	}
+	return CatchChance(k.MaxHP(), *k.apiKubeMon.Status.HP, catchRate, catchBonus)
+}
+
+// Catch makes the Trainer the owner of the wild KubeMon. The KubeMon leaves the Habitat it spawned in.
+func (k *KubeMon) Catch(trainer string) error {
+	if k.Owner() != "" {
+		return ErrNotWild
+	}
+
+	k.apiKubeMon.Spec.Owner = trainer
+	delete(k.apiKubeMon.Labels, kubemonv1.HabitatLabel)
+
+	refs := make([]metav1.OwnerReference, 0, len(k.apiKubeMon.OwnerReferences))
+	for _, ref := range k.apiKubeMon.OwnerReferences {
+		if ref.APIVersion == kubemonv1.GroupVersion.String() && ref.Kind == "Habitat" {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	k.apiKubeMon.OwnerReferences = refs
+
+	return k.update()
+}
@@ -0,0 +1,161 @@
+package kubemon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// InitialFriendship is the friendship a KubeMon starts with
+	InitialFriendship = 70
+	// MaxFriendship is the highest friendship a KubeMon can have
+	MaxFriendship = 255
+
+	// FriendshipWin is the friendship a KubeMon gains by winning a Fight
+	FriendshipWin = 3
+	// FriendshipLoss is the friendship a KubeMon gains by losing a Fight
+	FriendshipLoss = -1
+	// FriendshipHealed is the friendship a KubeMon gains by being healed
+	FriendshipHealed = 1
+	// FriendshipHealCooldown is how long a healed KubeMon does not gain friendship from being healed again
+	FriendshipHealCooldown = time.Hour
+
+	// MaxEvolutions is the number of times a KubeMon can evolve, so long chains of evolutions end
+	MaxEvolutions = 8
+
+	// dayStartHour and nightStartHour separate day and night
+	dayStartHour   = 6
+	nightStartHour = 18
+)
+
+var (
+	ErrEvolutionNotFound = errors.New("species the KubeMon evolves into does not exist")
+)
+
+// TimeOfDayAt returns the part of the day t lies in
+func TimeOfDayAt(t time.Time) kubemonv1.TimeOfDay {
+	if t.Hour() >= dayStartHour && t.Hour() < nightStartHour {
+		return kubemonv1.TimeOfDayDay
+	}
+	return kubemonv1.TimeOfDayNight
+}
+
+// UntilTimeOfDayChanges returns the time from t until the next day or night starts
+func UntilTimeOfDayChanges(t time.Time) time.Duration {
+	next := time.Date(t.Year(), t.Month(), t.Day(), dayStartHour, 0, 0, 0, t.Location())
+	if TimeOfDayAt(t) == kubemonv1.TimeOfDayDay {
+		next = next.Add((nightStartHour - dayStartHour) * time.Hour)
+	} else if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next.Sub(t)
+}
+
+// Friendship returns how attached the KubeMon is to its Trainer
+func (k *KubeMon) Friendship() int32 {
+	return ptr.Deref(k.apiKubeMon.Status.Friendship, InitialFriendship)
+}
+
+// addFriendship changes the friendship of the KubeMon by delta, keeping it between 0 and MaxFriendship.
+// The status is not persisted.
+func (k *KubeMon) addFriendship(delta int32) {
+	k.apiKubeMon.Status.Friendship = ptr.To(max(min(k.Friendship()+delta, MaxFriendship), 0))
+}
+
+// AddFriendship changes the friendship of the KubeMon by delta, keeping it between 0 and MaxFriendship
+func (k *KubeMon) AddFriendship(delta int32) error {
+	k.addFriendship(delta)
+	return k.updateStatus()
+}
+
+// AddHealFriendship raises the friendship of the KubeMon for being healed at the given time,
+// unless it already gained friendship from being healed within FriendshipHealCooldown
+func (k *KubeMon) AddHealFriendship(now time.Time) error {
+	last := k.apiKubeMon.Status.FriendshipHealedAt
+	if last != nil && now.Sub(last.Time) < FriendshipHealCooldown {
+		return nil
+	}
+
+	k.apiKubeMon.Status.FriendshipHealedAt = &metav1.Time{Time: now}
+	return k.AddFriendship(FriendshipHealed)
+}
+
+// Evolution returns the first evolution of the Species of the KubeMon whose triggers are met at the given time,
+// after the given Item was used on it. Evolutions triggered by an Item are only returned for that Item.
+// KubeMons taking part in a Fight do not evolve.
+func (k *KubeMon) Evolution(now time.Time, item string) *kubemonv1.Evolution {
+	if k.CurrentFight() != nil {
+		return nil
+	}
+	for i, evolution := range k.species.Spec.Evolutions {
+		if k.canEvolve(evolution, item) && (evolution.TimeOfDay == "" || evolution.TimeOfDay == TimeOfDayAt(now)) {
+			return &k.species.Spec.Evolutions[i]
+		}
+	}
+	return nil
+}
+
+// EvolvesAtOtherTimeOfDay reports whether the KubeMon would evolve without an Item, if it was another part of the day
+func (k *KubeMon) EvolvesAtOtherTimeOfDay(now time.Time) bool {
+	if k.CurrentFight() != nil {
+		return false
+	}
+	for _, evolution := range k.species.Spec.Evolutions {
+		if k.canEvolve(evolution, "") && evolution.TimeOfDay != "" && evolution.TimeOfDay != TimeOfDayAt(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// canEvolve reports whether the triggers of the evolution, except for the time of day, are met.
+// KubeMons never evolve into a Species they already had, so cycles of evolutions end, and evolve at most MaxEvolutions times.
+func (k *KubeMon) canEvolve(evolution kubemonv1.Evolution, item string) bool {
+	return evolution.Item == item &&
+		k.Level() >= ptr.Deref(evolution.MinLevel, 1) &&
+		k.Friendship() >= ptr.Deref(evolution.MinFriendship, 0) &&
+		!k.hadSpecies(evolution.Species) &&
+		len(k.apiKubeMon.Status.PreviousSpecies) < MaxEvolutions
+}
+
+// hadSpecies reports whether the KubeMon is or was of the Species
+func (k *KubeMon) hadSpecies(name string) bool {
+	status := &k.apiKubeMon.Status
+	return name == k.apiKubeMon.Spec.Species || name == status.EvolvedFrom || slices.Contains(status.PreviousSpecies, name)
+}
+
+// Evolve turns the KubeMon into the Species of the evolution. Its previous Species is recorded in the status
+// and its stats are recalculated, with the current HP growing by the same amount as the maximum HP.
+func (k *KubeMon) Evolve(evolution *kubemonv1.Evolution) error {
+	species := &kubemonv1.Species{}
+	if err := k.client.Get(k.ctx, types.NamespacedName{Name: evolution.Species}, species); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrEvolutionNotFound
+		}
+		return err
+	}
+
+	previous := k.apiKubeMon.Spec.Species
+	status := k.apiKubeMon.Status.DeepCopy()
+	k.apiKubeMon.Spec.Species = species.Name
+	if err := k.update(); err != nil {
+		return err
+	}
+
+	k.apiKubeMon.Status = *status
+	k.apiKubeMon.Status.EvolvedFrom = previous
+	k.apiKubeMon.Status.PreviousSpecies = append(k.apiKubeMon.Status.PreviousSpecies, previous)
+	k.species = species
+	k.recalculateStats()
+	k.updateCondition(kubemonv1.KubeMonConditionSpeciesResolved, metav1.ConditionTrue, ReasonSpeciesFound,
+		fmt.Sprintf("Species %q found", species.Name))
+	return k.updateStatus()
+}
@@ -0,0 +1,244 @@
+package kubemon
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var (
+	testNoon     = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	testMidnight = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	// testEvolvedSpecies is the Species testSpecies evolves into in the tests
+	testEvolvedSpecies = &kubemonv1.Species{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploymon"},
+		Spec: kubemonv1.SpeciesSpec{
+			BaseHP:      60,
+			BaseAttack:  62,
+			BaseDefense: 63,
+			BaseSpeed:   60,
+			Type:        "container",
+			GrowthRate:  kubemonv1.GrowthRateMedium,
+		},
+	}
+)
+
+func TestTimeOfDayAt(t *testing.T) {
+	tests := []struct {
+		hour int
+		want kubemonv1.TimeOfDay
+	}{
+		{hour: 0, want: kubemonv1.TimeOfDayNight},
+		{hour: 5, want: kubemonv1.TimeOfDayNight},
+		{hour: 6, want: kubemonv1.TimeOfDayDay},
+		{hour: 17, want: kubemonv1.TimeOfDayDay},
+		{hour: 18, want: kubemonv1.TimeOfDayNight},
+		{hour: 23, want: kubemonv1.TimeOfDayNight},
+	}
+	for _, tt := range tests {
+		if got := TimeOfDayAt(testMidnight.Add(time.Duration(tt.hour) * time.Hour)); got != tt.want {
+			t.Errorf("TimeOfDayAt(%02d:00) = %s, want %s", tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestUntilTimeOfDayChanges(t *testing.T) {
+	tests := []struct {
+		name string
+		at   time.Duration
+		want time.Duration
+	}{
+		{name: "midnight until morning", at: 0, want: 6 * time.Hour},
+		{name: "early morning until morning", at: 5*time.Hour + 30*time.Minute, want: 30 * time.Minute},
+		{name: "morning until evening", at: 6 * time.Hour, want: 12 * time.Hour},
+		{name: "noon until evening", at: 12 * time.Hour, want: 6 * time.Hour},
+		{name: "last second of the day", at: 18*time.Hour - time.Second, want: time.Second},
+		{name: "evening until the next morning", at: 18 * time.Hour, want: 12 * time.Hour},
+		{name: "late night until the next morning", at: 23 * time.Hour, want: 7 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UntilTimeOfDayChanges(testMidnight.Add(tt.at)); got != tt.want {
+				t.Errorf("UntilTimeOfDayChanges() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvolution(t *testing.T) {
+	tests := []struct {
+		name       string
+		evolutions []kubemonv1.Evolution
+		level      int32
+		friendship int32
+		item       string
+		now        time.Time
+		fighting   bool
+		previous   []string
+		evolvedTo  string
+	}{
+		{name: "level reached", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}}, level: 16, evolvedTo: "deploymon"},
+		{name: "level not reached", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}}, level: 15},
+		{name: "friendship reached", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinFriendship: ptr.To(int32(220))}}, friendship: 220, evolvedTo: "deploymon"},
+		{name: "friendship not reached", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinFriendship: ptr.To(int32(220))}}, friendship: 219},
+		{name: "all triggers have to be met", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16)), MinFriendship: ptr.To(int32(220))}}, level: 16, friendship: 219},
+		{name: "item used", evolutions: []kubemonv1.Evolution{{Species: "deploymon", Item: "fire-stone"}}, item: "fire-stone", evolvedTo: "deploymon"},
+		{name: "item not used", evolutions: []kubemonv1.Evolution{{Species: "deploymon", Item: "fire-stone"}}},
+		{name: "other item used", evolutions: []kubemonv1.Evolution{{Species: "deploymon", Item: "fire-stone"}}, item: "water-stone"},
+		{name: "evolutions without an item ignore used items", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}}, level: 16, item: "fire-stone"},
+		{name: "time of day matches", evolutions: []kubemonv1.Evolution{{Species: "deploymon", TimeOfDay: kubemonv1.TimeOfDayNight}}, now: testMidnight, evolvedTo: "deploymon"},
+		{name: "time of day does not match", evolutions: []kubemonv1.Evolution{{Species: "deploymon", TimeOfDay: kubemonv1.TimeOfDayNight}}, now: testNoon},
+		{
+			name: "first evolution whose triggers are met",
+			evolutions: []kubemonv1.Evolution{
+				{Species: "statefulmon", MinLevel: ptr.To(int32(30))},
+				{Species: "deploymon", MinLevel: ptr.To(int32(16))},
+				{Species: "daemonmon", MinLevel: ptr.To(int32(16))},
+			},
+			level:     20,
+			evolvedTo: "deploymon",
+		},
+		{name: "fighting KubeMons do not evolve", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}}, level: 16, fighting: true},
+		{name: "not into its own Species", evolutions: []kubemonv1.Evolution{{Species: testSpecies.Name, MinLevel: ptr.To(int32(16))}}, level: 16},
+		{name: "not back into a previous Species", evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}}, level: 16, previous: []string{"deploymon", "replicaling"}},
+		{
+			name:       "at most MaxEvolutions times",
+			evolutions: []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(16))}},
+			level:      16,
+			previous:   []string{"a", "b", "c", "d", "e", "f", "g", "h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := newTestKubeMon(t, max(tt.level, 1))
+			mon.species.Spec.Evolutions = tt.evolutions
+			if tt.friendship != 0 {
+				mon.apiKubeMon.Status.Friendship = ptr.To(tt.friendship)
+			}
+			if tt.fighting {
+				mon.apiKubeMon.Status.CurrentFight = &kubemonv1.FightReference{Namespace: "default", Name: "fight-sample"}
+			}
+			mon.apiKubeMon.Status.PreviousSpecies = tt.previous
+			now := tt.now
+			if now.IsZero() {
+				now = testNoon
+			}
+
+			evolution := mon.Evolution(now, tt.item)
+			if tt.evolvedTo == "" && evolution != nil {
+				t.Errorf("Evolution() = %s, want none", evolution.Species)
+			}
+			if tt.evolvedTo != "" && (evolution == nil || evolution.Species != tt.evolvedTo) {
+				t.Errorf("Evolution() = %v, want %s", evolution, tt.evolvedTo)
+			}
+		})
+	}
+}
+
+func TestEvolvesAtOtherTimeOfDay(t *testing.T) {
+	tests := []struct {
+		name      string
+		evolution kubemonv1.Evolution
+		now       time.Time
+		want      bool
+	}{
+		{name: "at night during the day", evolution: kubemonv1.Evolution{Species: "deploymon", TimeOfDay: kubemonv1.TimeOfDayNight}, now: testNoon, want: true},
+		{name: "at night during the night", evolution: kubemonv1.Evolution{Species: "deploymon", TimeOfDay: kubemonv1.TimeOfDayNight}, now: testMidnight, want: false},
+		{name: "at any time", evolution: kubemonv1.Evolution{Species: "deploymon", MinLevel: ptr.To(int32(1))}, now: testNoon, want: false},
+		{name: "other triggers not met", evolution: kubemonv1.Evolution{Species: "deploymon", MinLevel: ptr.To(int32(16)), TimeOfDay: kubemonv1.TimeOfDayNight}, now: testNoon, want: false},
+		{name: "item needed", evolution: kubemonv1.Evolution{Species: "deploymon", Item: "moon-stone", TimeOfDay: kubemonv1.TimeOfDayNight}, now: testNoon, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := newTestKubeMon(t, 5)
+			mon.species.Spec.Evolutions = []kubemonv1.Evolution{tt.evolution}
+			if got := mon.EvolvesAtOtherTimeOfDay(tt.now); got != tt.want {
+				t.Errorf("EvolvesAtOtherTimeOfDay() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFriendshipEvolution(t *testing.T) {
+	mon := newTestKubeMon(t, 5)
+	mon.species.Spec.Evolutions = []kubemonv1.Evolution{{Species: "deploymon", MinFriendship: ptr.To(int32(InitialFriendship + FriendshipHealed))}}
+
+	if evolution := mon.Evolution(testNoon, ""); evolution != nil {
+		t.Fatalf("Evolution() with initial friendship = %s, want none", evolution.Species)
+	}
+	if err := mon.AddHealFriendship(testNoon); err != nil {
+		t.Fatal(err)
+	}
+	if evolution := mon.Evolution(testNoon, ""); evolution == nil || evolution.Species != "deploymon" {
+		t.Errorf("Evolution() after being healed = %v, want deploymon", evolution)
+	}
+
+	if err := mon.AddHealFriendship(testNoon.Add(FriendshipHealCooldown - time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if mon.Friendship() != InitialFriendship+FriendshipHealed {
+		t.Errorf("Friendship() after being healed again within the cooldown = %d, want %d", mon.Friendship(), InitialFriendship+FriendshipHealed)
+	}
+	if err := mon.AddFriendship(MaxFriendship); err != nil {
+		t.Fatal(err)
+	}
+	if mon.Friendship() != MaxFriendship {
+		t.Errorf("Friendship() = %d, want at most %d", mon.Friendship(), MaxFriendship)
+	}
+}
+
+func TestEvolve(t *testing.T) {
+	mon := newTestKubeMon(t, 20, testEvolvedSpecies.DeepCopy())
+	if err := mon.SetHealth(mon.MaxHP() - 5); err != nil {
+		t.Fatal(err)
+	}
+	maxHP := mon.MaxHP()
+
+	if err := mon.Evolve(&kubemonv1.Evolution{Species: "deploymon", MinLevel: ptr.To(int32(16))}); err != nil {
+		t.Fatal(err)
+	}
+	if mon.Species().Name != "deploymon" || mon.Object().Spec.Species != "deploymon" {
+		t.Errorf("Species after evolving = %s, spec %s, want deploymon", mon.Species().Name, mon.Object().Spec.Species)
+	}
+	status := mon.Object().Status
+	if status.EvolvedFrom != testSpecies.Name {
+		t.Errorf("EvolvedFrom = %q, want %q", status.EvolvedFrom, testSpecies.Name)
+	}
+	if len(status.PreviousSpecies) != 1 || status.PreviousSpecies[0] != testSpecies.Name {
+		t.Errorf("PreviousSpecies = %v, want [%s]", status.PreviousSpecies, testSpecies.Name)
+	}
+	if want := CalculateMaxHP(testEvolvedSpecies.Spec.BaseHP, 20); mon.MaxHP() != want || mon.MaxHP() <= maxHP {
+		t.Errorf("MaxHP after evolving = %d, want %d", mon.MaxHP(), want)
+	}
+	if mon.HP() != mon.MaxHP()-5 {
+		t.Errorf("HP after evolving = %d, want %d", mon.HP(), mon.MaxHP()-5)
+	}
+
+	if err := mon.Evolve(&kubemonv1.Evolution{Species: "missing", MinLevel: ptr.To(int32(1))}); err != ErrEvolutionNotFound {
+		t.Errorf("Evolve() into a missing Species = %v, want %v", err, ErrEvolutionNotFound)
+	}
+}
+
+func TestEvolutionCycle(t *testing.T) {
+	// deploymon evolves back into podling, which would evolve into deploymon again
+	evolved := testEvolvedSpecies.DeepCopy()
+	evolved.Spec.Evolutions = []kubemonv1.Evolution{{Species: testSpecies.Name, MinLevel: ptr.To(int32(1))}}
+	mon := newTestKubeMon(t, 20, evolved)
+	mon.species.Spec.Evolutions = []kubemonv1.Evolution{{Species: "deploymon", MinLevel: ptr.To(int32(1))}}
+
+	evolution := mon.Evolution(testNoon, "")
+	if evolution == nil {
+		t.Fatal("Evolution() = none, want deploymon")
+	}
+	if err := mon.Evolve(evolution); err != nil {
+		t.Fatal(err)
+	}
+	if evolution := mon.Evolution(testNoon, ""); evolution != nil {
+		t.Errorf("Evolution() of deploymon = %s, want none as the KubeMon was a %s before", evolution.Species, testSpecies.Name)
+	}
+}
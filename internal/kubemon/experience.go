@@ -0,0 +1,59 @@
+package kubemon
+
+import (
+	"math"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	MaxLevel int32 = 100
+
+	DefaultBaseExperience int32 = 64
+
+	FightPayoutPerLevel int64 = 10
+)
+
+// ExperienceForLevel returns the total amount of experience needed to reach the given level
+func ExperienceForLevel(rate kubemonv1.GrowthRate, level int32) int32 {
+	n := int64(level)
+	cube := n * n * n
+
+	switch rate {
+	case kubemonv1.GrowthRateFast:
+		return int32(4 * cube / 5)
+	case kubemonv1.GrowthRateSlow:
+		return int32(5 * cube / 4)
+	case kubemonv1.GrowthRateErratic:
+		switch {
+		case n < 50:
+			return int32(cube * (100 - n) / 50)
+		case n < 68:
+			return int32(cube * (150 - n) / 100)
+		case n < 98:
+			return int32(cube * ((1911 - 10*n) / 3) / 500)
+		default:
+			return int32(cube * (160 - n) / 100)
+		}
+	default:
+		return int32(cube)
+	}
+}
+
+// ExperienceYield returns the experience a winner of the given level gains for defeating the loser.
+// Defeating higher levelled KubeMons yields more experience than defeating lower levelled ones.
+func ExperienceYield(baseExperience, loserLevel, winnerLevel int32) int32 {
+	if baseExperience <= 0 {
+		baseExperience = DefaultBaseExperience
+	}
+
+	base := float64(baseExperience) * float64(loserLevel) / 5
+	scale := math.Pow(float64(2*loserLevel+10)/float64(loserLevel+winnerLevel+10), 2.5)
+
+	return int32(base*scale) + 1
+}
+
+// FightPayout returns the coins the Trainer of the winner of a Fight receives for defeating a KubeMon of the given level
+func FightPayout(loserLevel int32) int64 {
+	return FightPayoutPerLevel * int64(loserLevel)
+}
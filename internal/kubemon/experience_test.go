@@ -0,0 +1,72 @@
+package kubemon
+
+import (
+	"testing"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+func TestExperienceForLevel(t *testing.T) {
+	tests := []struct {
+		rate  kubemonv1.GrowthRate
+		level int32
+		want  int32
+	}{
+		{rate: kubemonv1.GrowthRateFast, level: 10, want: 800},
+		{rate: kubemonv1.GrowthRateMedium, level: 10, want: 1000},
+		{rate: kubemonv1.GrowthRateSlow, level: 10, want: 1250},
+		{rate: kubemonv1.GrowthRateErratic, level: 10, want: 1800},
+		{rate: kubemonv1.GrowthRateErratic, level: 60, want: 194400},
+		{rate: kubemonv1.GrowthRateErratic, level: 80, want: 378880},
+		{rate: kubemonv1.GrowthRateErratic, level: 100, want: 600000},
+		{rate: kubemonv1.GrowthRateMedium, level: 100, want: 1000000},
+	}
+	for _, tt := range tests {
+		if got := ExperienceForLevel(tt.rate, tt.level); got != tt.want {
+			t.Errorf("ExperienceForLevel(%s, %d) = %d, want %d", tt.rate, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestExperienceForLevelIncreases(t *testing.T) {
+	for _, rate := range []kubemonv1.GrowthRate{kubemonv1.GrowthRateFast, kubemonv1.GrowthRateMedium, kubemonv1.GrowthRateSlow, kubemonv1.GrowthRateErratic} {
+		for level := int32(2); level <= MaxLevel; level++ {
+			if ExperienceForLevel(rate, level) <= ExperienceForLevel(rate, level-1) {
+				t.Errorf("%s: level %d does not need more experience than level %d", rate, level, level-1)
+			}
+		}
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      int32
+		xp         int32
+		wantLevels int32
+		wantLevel  int32
+	}{
+		{name: "not enough for a level", level: 5, xp: 10, wantLevels: 0, wantLevel: 5},
+		{name: "exactly one level", level: 5, xp: ExperienceForLevel(kubemonv1.GrowthRateMedium, 6) - ExperienceForLevel(kubemonv1.GrowthRateMedium, 5), wantLevels: 1, wantLevel: 6},
+		{name: "several levels at once", level: 5, xp: ExperienceForLevel(kubemonv1.GrowthRateMedium, 10), wantLevels: 5, wantLevel: 10},
+		{name: "capped at the maximum level", level: 99, xp: 10_000_000, wantLevels: 1, wantLevel: MaxLevel},
+		{name: "no levels beyond the maximum", level: MaxLevel, xp: 10_000_000, wantLevels: 0, wantLevel: MaxLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := newTestKubeMon(t, tt.level)
+			maxHP := mon.MaxHP()
+
+			levels, err := mon.GainExperience(tt.xp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if levels != tt.wantLevels || mon.Level() != tt.wantLevel {
+				t.Errorf("GainExperience(%d) = %d levels, now at level %d, want %d levels, level %d", tt.xp, levels, mon.Level(), tt.wantLevels, tt.wantLevel)
+			}
+			if levels > 0 && mon.MaxHP() <= maxHP {
+				t.Errorf("maximum HP did not grow with the level: %d, was %d", mon.MaxHP(), maxHP)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package kubemon
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var (
+	ErrItemNoEffect = errors.New("item would have no effect on the KubeMon")
+)
+
+// CanUseItem reports whether using the Item on the KubeMon would have an effect
+func (k *KubeMon) CanUseItem(item *kubemonv1.Item) bool {
+	hp := *k.apiKubeMon.Status.HP
+
+	switch item.Spec.Category {
+	case kubemonv1.ItemCategoryPotion:
+		return hp > 0 && hp < k.MaxHP() && item.Spec.HealAmount > 0
+	case kubemonv1.ItemCategoryRevive:
+		return hp == 0
+	case kubemonv1.ItemCategoryStatBooster:
+		return item.Spec.Boost > 0 && item.Spec.Stat != ""
+	case kubemonv1.ItemCategoryCure:
+		return k.Ailment() != "" && (len(item.Spec.Cures) == 0 || slices.Contains(item.Spec.Cures, k.Ailment()))
+	case kubemonv1.ItemCategoryEvolution:
+		return k.Evolution(time.Now(), item.Name) != nil
+	default:
+		return false
+	}
+}
+
+// UseItem applies the effect of the Item to the KubeMon
+func (k *KubeMon) UseItem(item *kubemonv1.Item) error {
+	if !k.CanUseItem(item) {
+		return ErrItemNoEffect
+	}
+
+	status := &k.apiKubeMon.Status
+	switch item.Spec.Category {
+	case kubemonv1.ItemCategoryPotion:
+		return k.AddHealth(item.Spec.HealAmount)
+	case kubemonv1.ItemCategoryRevive:
+		return k.SetHealth(max(k.MaxHP()/2, 1))
+	case kubemonv1.ItemCategoryStatBooster:
+		switch item.Spec.Stat {
+		case kubemonv1.StatHP:
+			status.Boosts.HP += item.Spec.Boost
+		case kubemonv1.StatAttack:
+			status.Boosts.Attack += item.Spec.Boost
+		case kubemonv1.StatDefense:
+			status.Boosts.Defense += item.Spec.Boost
+		case kubemonv1.StatSpeed:
+			status.Boosts.Speed += item.Spec.Boost
+		}
+		k.recalculateStats()
+		return k.updateStatus()
+	case kubemonv1.ItemCategoryCure:
+		return k.CureAilment()
+	case kubemonv1.ItemCategoryEvolution:
+		evolution := k.Evolution(time.Now(), item.Name)
+		if evolution == nil {
+			return ErrItemNoEffect
+		}
+		return k.Evolve(evolution)
+	}
+	return nil
+}
@@ -2,8 +2,16 @@ package kubemon
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -14,11 +22,28 @@ type KubeMon struct {
 	ctx          context.Context
 
 	apiKubeMon *kubemonv1.KubeMon
+	species    *kubemonv1.Species
+	moves      []Move
+	// simulated KubeMons keep all changes in memory
+	simulated bool
 }
 
 const (
 	KubeMonActionAnnotation = "KubeMon/action"
 	KubeMonActionHeal       = "heal"
+	// KubeMonActionUseItemPrefix is followed by the name of the Item to use, e.g. "use-item:potion"
+	KubeMonActionUseItemPrefix = "use-item:"
+)
+
+const (
+	ReasonSpeciesFound    = "SpeciesFound"
+	ReasonSpeciesNotFound = "SpeciesNotFound"
+	ReasonMovesFound      = "MovesFound"
+	ReasonMovesNotFound   = "MovesNotFound"
+)
+
+var (
+	ErrSpeciesNotFound = errors.New("species of KubeMon does not exist")
 )
 
 func New(ctx context.Context, c client.Client, sc client.SubResourceWriter, apiKubeMon *kubemonv1.KubeMon) (*KubeMon, error) {
@@ -37,24 +62,182 @@ func New(ctx context.Context, c client.Client, sc client.SubResourceWriter, apiK
 }
 
 func (k *KubeMon) init() error {
+	if err := k.loadSpecies(); err != nil {
+		return err
+	}
+	if err := k.loadMoves(); err != nil {
+		return err
+	}
+
+	if k.apiKubeMon.Status.Level == nil {
+
+		if err := k.SetLevel(ptr.Deref(k.apiKubeMon.Spec.InitialLevel, 1)); err != nil {
+			return err
+		}
+	}
+	if k.apiKubeMon.Status.XP == nil {
+		k.apiKubeMon.Status.XP = ptr.To(ExperienceForLevel(k.species.Spec.GrowthRate, *k.apiKubeMon.Status.Level))
+		if err := k.updateStatus(); err != nil {
+			return err
+		}
+	}
+	if k.apiKubeMon.Status.Friendship == nil {
+		k.apiKubeMon.Status.Friendship = ptr.To(int32(InitialFriendship))
+		if err := k.updateStatus(); err != nil {
+			return err
+		}
+	}
+	if k.recalculateStats() {
+		if err := k.updateStatus(); err != nil {
+			return err
+		}
+	}
 	if k.apiKubeMon.Status.HP == nil {
-		if err := k.SetHealth(10); err != nil {
+		if err := k.SetHealth(k.MaxHP()); err != nil {
 			return err
 		}
 	}
-	if k.apiKubeMon.Status.Level == nil {
+	return nil
+}
+
+// recalculateStats derives the stats of the KubeMon from its Species and level and reports whether they changed.
+// If the maximum HP grows, the current HP grows by the same amount.
+func (k *KubeMon) recalculateStats() bool {
+	status := &k.apiKubeMon.Status
+	spec := k.species.Spec
+	level := *status.Level
 
-		if err := k.SetLevel(1); err != nil {
+	maxHP := CalculateMaxHP(spec.BaseHP, level) + status.Boosts.HP
+	attack := CalculateStat(spec.BaseAttack, level) + status.Boosts.Attack
+	defense := CalculateStat(spec.BaseDefense, level) + status.Boosts.Defense
+	speed := CalculateStat(spec.BaseSpeed, level) + status.Boosts.Speed
+
+	if ptr.Equal(status.MaxHP, &maxHP) && ptr.Equal(status.Attack, &attack) &&
+		ptr.Equal(status.Defense, &defense) && ptr.Equal(status.Speed, &speed) {
+		return false
+	}
+
+	if status.HP != nil {
+		hp := *status.HP
+		if status.MaxHP != nil && maxHP > *status.MaxHP && hp > 0 {
+			hp += maxHP - *status.MaxHP
+		}
+		status.HP = ptr.To(min(hp, maxHP))
+	}
+
+	status.MaxHP = ptr.To(maxHP)
+	status.Attack = ptr.To(attack)
+	status.Defense = ptr.To(defense)
+	status.Speed = ptr.To(speed)
+
+	return true
+}
+
+// loadSpecies resolves the Species of the KubeMon and records the outcome in its SpeciesResolved condition
+func (k *KubeMon) loadSpecies() error {
+	speciesName := k.apiKubeMon.Spec.Species
+
+	species := &kubemonv1.Species{}
+	err := k.client.Get(k.ctx, types.NamespacedName{Name: speciesName}, species)
+	if speciesName == "" || apierrors.IsNotFound(err) {
+		if err := k.setCondition(kubemonv1.KubeMonConditionSpeciesResolved, metav1.ConditionFalse, ReasonSpeciesNotFound,
+			fmt.Sprintf("Species %q does not exist", speciesName)); err != nil {
 			return err
 		}
+		return ErrSpeciesNotFound
 	}
-	return nil
+	if err != nil {
+		return err
+	}
+
+	k.species = species
+	return k.setCondition(kubemonv1.KubeMonConditionSpeciesResolved, metav1.ConditionTrue, ReasonSpeciesFound,
+		fmt.Sprintf("Species %q found", speciesName))
+}
+
+// loadMoves resolves the Moves known by the KubeMon and keeps track of their PP in its status.
+// Moves which do not exist are reported in the MovesResolved condition and cannot be used.
+func (k *KubeMon) loadMoves() error {
+	k.moves = nil
+
+	var missing []string
+	for _, name := range k.apiKubeMon.Spec.Moves {
+		move := &kubemonv1.Move{}
+		if err := k.client.Get(k.ctx, types.NamespacedName{Name: name}, move); err != nil {
+			if apierrors.IsNotFound(err) {
+				missing = append(missing, name)
+				continue
+			}
+			return err
+		}
+		k.moves = append(k.moves, Move{Name: name, Spec: move.Spec})
+	}
+
+	statusMoves := make([]kubemonv1.KubeMonMove, 0, len(k.moves))
+	for _, move := range k.moves {
+		pp := move.Spec.PP
+		for _, known := range k.apiKubeMon.Status.Moves {
+			if known.Name == move.Name {
+				pp = min(known.PP, move.Spec.PP)
+			}
+		}
+		statusMoves = append(statusMoves, kubemonv1.KubeMonMove{Name: move.Name, PP: pp})
+	}
+	changed := !equality.Semantic.DeepEqual(statusMoves, k.apiKubeMon.Status.Moves)
+	k.apiKubeMon.Status.Moves = statusMoves
+
+	if len(missing) > 0 {
+		changed = k.updateCondition(kubemonv1.KubeMonConditionMovesResolved, metav1.ConditionFalse, ReasonMovesNotFound,
+			fmt.Sprintf("Moves %s do not exist", strings.Join(missing, ", "))) || changed
+	} else {
+		changed = k.updateCondition(kubemonv1.KubeMonConditionMovesResolved, metav1.ConditionTrue, ReasonMovesFound,
+			"All moves found") || changed
+	}
+
+	if !changed {
+		return nil
+	}
+	return k.updateStatus()
+}
+
+func (k *KubeMon) setCondition(conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	if !k.updateCondition(conditionType, status, reason, message) {
+		return nil
+	}
+	return k.updateStatus()
+}
+
+func (k *KubeMon) updateCondition(conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	return meta.SetStatusCondition(&k.apiKubeMon.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: k.apiKubeMon.Generation,
+	})
+}
+
+// Object returns the KubeMon object the KubeMon is backed by
+func (k *KubeMon) Object() *kubemonv1.KubeMon {
+	return k.apiKubeMon
 }
 
 func (k *KubeMon) Name() string {
 	return k.apiKubeMon.Name
 }
 
+func (k *KubeMon) Namespace() string {
+	return k.apiKubeMon.Namespace
+}
+
+func (k *KubeMon) Owner() string {
+	return k.apiKubeMon.Spec.Owner
+}
+
+func (k *KubeMon) Species() *kubemonv1.Species {
+	return k.species
+}
+
 func (k *KubeMon) GetAction() string {
 	return k.apiKubeMon.Annotations[KubeMonActionAnnotation]
 }
@@ -73,6 +256,10 @@ func (k *KubeMon) IsDead() bool {
 
 func (k *KubeMon) SetHealth(health int32) error {
 	k.apiKubeMon.Status.HP = ptr.To(health)
+	if health == 0 {
+		// Fainting cures all ailments
+		k.apiKubeMon.Status.Ailment = nil
+	}
 	if err := k.updateStatus(); err != nil {
 		return err
 	}
@@ -80,8 +267,9 @@ func (k *KubeMon) SetHealth(health int32) error {
 	return nil
 }
 
+// AddHealth heals the KubeMon by the given amount, without exceeding its maximum HP
 func (k *KubeMon) AddHealth(health int32) error {
-	k.apiKubeMon.Status.HP = ptr.To(*k.apiKubeMon.Status.HP + health)
+	k.apiKubeMon.Status.HP = ptr.To(min(*k.apiKubeMon.Status.HP+health, k.MaxHP()))
 	if err := k.updateStatus(); err != nil {
 		return err
 	}
@@ -95,22 +283,14 @@ func (k *KubeMon) GetDamage(damage int32) error {
 		newHP = 0
 	}
 
-	k.apiKubeMon.Status.HP = ptr.To(newHP)
-	if err := k.updateStatus(); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (k *KubeMon) Attack(k2 *KubeMon) error {
-	if err := k2.GetDamage(k.apiKubeMon.Spec.Strength); err != nil {
-		return err
-	}
-	return nil
+	return k.SetHealth(newHP)
 }
 
 func (k *KubeMon) SetLevel(level int32) error {
 	k.apiKubeMon.Status.Level = ptr.To(level)
+	if k.apiKubeMon.Status.MaxHP != nil {
+		k.recalculateStats()
+	}
 	if err := k.updateStatus(); err != nil {
 		return err
 	}
@@ -119,14 +299,75 @@ func (k *KubeMon) SetLevel(level int32) error {
 }
 
 func (k *KubeMon) LevelUp() error {
-	k.apiKubeMon.Status.Level = ptr.To(int32(*k.apiKubeMon.Status.Level + 1))
+	return k.SetLevel(*k.apiKubeMon.Status.Level + 1)
+}
+
+func (k *KubeMon) HP() int32 {
+	return *k.apiKubeMon.Status.HP
+}
+
+func (k *KubeMon) MaxHP() int32 {
+	return *k.apiKubeMon.Status.MaxHP
+}
+
+// Speed returns the speed of the KubeMon in a Fight, which is halved by paralysis
+func (k *KubeMon) Speed() int32 {
+	if k.Ailment() == kubemonv1.AilmentParalysis {
+		return *k.apiKubeMon.Status.Speed / 2
+	}
+	return *k.apiKubeMon.Status.Speed
+}
+
+func (k *KubeMon) Level() int32 {
+	return *k.apiKubeMon.Status.Level
+}
+
+// GainExperience adds experience to the KubeMon and levels it up according to the growth rate of its Species.
+// It returns the number of levels gained.
+func (k *KubeMon) GainExperience(xp int32) (int32, error) {
+	levels := k.gainExperience(xp)
 	if err := k.updateStatus(); err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return levels, nil
+}
+
+// gainExperience adds experience to the KubeMon and returns the number of levels gained. The status is not persisted.
+func (k *KubeMon) gainExperience(xp int32) int32 {
+	k.apiKubeMon.Status.XP = ptr.To(*k.apiKubeMon.Status.XP + xp)
+
+	var levels int32
+	level := *k.apiKubeMon.Status.Level
+	for level < MaxLevel && *k.apiKubeMon.Status.XP >= ExperienceForLevel(k.species.Spec.GrowthRate, level+1) {
+		level++
+		levels++
+	}
+	k.apiKubeMon.Status.Level = ptr.To(level)
+	k.recalculateStats()
+	return levels
+}
+
+// ExperienceYield returns the experience another KubeMon gains by defeating this one
+func (k *KubeMon) ExperienceYield(winner *KubeMon) int32 {
+	return ExperienceYield(k.species.Spec.BaseExperience, k.Level(), winner.Level())
+}
+
+// Simulate returns a copy of the KubeMon, whose changes are only kept in memory
+func (k *KubeMon) Simulate() *KubeMon {
+	sim := *k
+	sim.apiKubeMon = k.apiKubeMon.DeepCopy()
+	sim.simulated = true
+	return &sim
 }
 
 func (k *KubeMon) updateStatus() error {
+	if k.simulated {
+		return nil
+	}
+	status := &k.apiKubeMon.Status
+	if status.HP != nil && status.MaxHP != nil {
+		status.Health = fmt.Sprintf("%d/%d", *status.HP, *status.MaxHP)
+	}
 	if err := k.statusClient.Update(k.ctx, k.apiKubeMon); err != nil {
 		return err
 	}
@@ -134,6 +375,9 @@ func (k *KubeMon) updateStatus() error {
 }
 
 func (k *KubeMon) update() error {
+	if k.simulated {
+		return nil
+	}
 	if err := k.client.Update(k.ctx, k.apiKubeMon); err != nil {
 		return err
 	}
@@ -0,0 +1,79 @@
+package kubemon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+// testSpecies is the Species of the KubeMons created by newTestKubeMon
+var testSpecies = &kubemonv1.Species{
+	ObjectMeta: metav1.ObjectMeta{Name: "podling"},
+	Spec: kubemonv1.SpeciesSpec{
+		BaseHP:      45,
+		BaseAttack:  49,
+		BaseDefense: 49,
+		BaseSpeed:   45,
+		Type:        "container",
+		GrowthRate:  kubemonv1.GrowthRateMedium,
+	},
+}
+
+// newTestKubeMon returns an initialized KubeMon of testSpecies at the given level, backed by a fake client.
+// The objects are created alongside it, e.g. the Species it evolves into.
+func newTestKubeMon(t *testing.T, level int32, objects ...client.Object) *KubeMon {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := kubemonv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	apiMon := &kubemonv1.KubeMon{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubemon-sample", Namespace: "default"},
+		Spec:       kubemonv1.KubeMonSpec{Species: testSpecies.Name, Strength: 1, InitialLevel: ptr.To(level)},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&kubemonv1.KubeMon{}).
+		WithObjects(append([]client.Object{testSpecies.DeepCopy(), apiMon}, objects...)...).
+		Build()
+
+	mon, err := New(context.Background(), c, c.Status(), apiMon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mon
+}
+
+func TestAddHealth(t *testing.T) {
+	mon := newTestKubeMon(t, 5)
+	maxHP := mon.MaxHP()
+
+	if err := mon.SetHealth(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := mon.AddHealth(5); err != nil {
+		t.Fatal(err)
+	}
+	if mon.HP() != 6 {
+		t.Errorf("HP after healing 5 = %d, want 6", mon.HP())
+	}
+
+	if err := mon.AddHealth(maxHP); err != nil {
+		t.Fatal(err)
+	}
+	if mon.HP() != maxHP {
+		t.Errorf("HP after healing beyond the maximum = %d, want %d", mon.HP(), maxHP)
+	}
+	if want := fmt.Sprintf("%d/%d", maxHP, maxHP); mon.Object().Status.Health != want {
+		t.Errorf("Health = %q, want %q", mon.Object().Status.Health, want)
+	}
+}
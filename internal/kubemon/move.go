@@ -0,0 +1,225 @@
+package kubemon
+
+import (
+	"fmt"
+	"math/rand"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const (
+	// StruggleMoveName is the name of the Move used by KubeMons which have no other Move left to use
+	StruggleMoveName = "struggle"
+)
+
+// Move is a Move a KubeMon can use in a fight
+type Move struct {
+	Name string
+	Spec kubemonv1.MoveSpec
+}
+
+// AttackResult describes the outcome of a KubeMon using a Move
+type AttackResult struct {
+	Attacker   string
+	Defender   string
+	Move       string
+	Damage     int32
+	Healed     int32
+	DefenderHP int32
+	Critical   bool
+	Missed     bool
+	// Effectiveness is how well the Move worked against the type of the defender
+	Effectiveness Effectiveness
+	// Ailment is the status ailment the Move inflicted on the defender
+	Ailment kubemonv1.Ailment
+}
+
+// Message returns a human readable description of the attack
+func (r *AttackResult) Message() string {
+	message := fmt.Sprintf("%s used %s", r.Attacker, r.Move)
+	if r.Missed {
+		return message + fmt.Sprintf(" on %s, but missed", r.Defender)
+	}
+	if r.Effectiveness == EffectivenessNoEffect {
+		return message + fmt.Sprintf(" on %s, but it had no effect", r.Defender)
+	}
+	if r.Critical {
+		message += ", landing a critical hit,"
+	}
+	if r.Damage > 0 {
+		message += fmt.Sprintf(" on %s and dealt %d damage (%d HP left)", r.Defender, r.Damage, r.DefenderHP)
+	} else if r.Ailment != "" {
+		message += " on " + r.Defender
+	}
+	switch r.Effectiveness {
+	case EffectivenessSuperEffective:
+		message += ", it's super effective"
+	case EffectivenessNotVeryEffective:
+		message += ", it's not very effective"
+	}
+	if r.Ailment != "" {
+		message += fmt.Sprintf(", %s %s", r.Defender, ailmentInflicted[r.Ailment])
+	}
+	if r.Healed > 0 {
+		message += fmt.Sprintf(", restoring %d HP", r.Healed)
+	}
+	return message
+}
+
+// struggle returns the Move of a KubeMon without any usable Move, whose power is the KubeMon's strength
+func (k *KubeMon) struggle() Move {
+	return Move{
+		Name: StruggleMoveName,
+		Spec: kubemonv1.MoveSpec{
+			Power:    k.apiKubeMon.Spec.Strength,
+			Accuracy: 100,
+			Effect:   kubemonv1.MoveEffectRecoil,
+		},
+	}
+}
+
+// Moves returns the Moves of the KubeMon which have PP left
+func (k *KubeMon) Moves() []Move {
+	moves := make([]Move, 0, len(k.moves))
+	for _, move := range k.moves {
+		if k.pp(move.Name) > 0 {
+			moves = append(moves, move)
+		}
+	}
+	return moves
+}
+
+// Move returns the usable Move of the KubeMon with the given name
+func (k *KubeMon) Move(name string) (Move, bool) {
+	for _, move := range k.Moves() {
+		if move.Name == name {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+// SelectMove picks the Move the KubeMon uses against the given opponent.
+// A KubeMon with low HP prefers healing, otherwise the strongest Move is picked.
+func (k *KubeMon) SelectMove(opponent *KubeMon) Move {
+	moves := k.Moves()
+	if len(moves) == 0 {
+		return k.struggle()
+	}
+
+	if *k.apiKubeMon.Status.HP <= k.MaxHP()/3 {
+		for _, move := range moves {
+			if move.Spec.Effect == kubemonv1.MoveEffectHeal {
+				return move
+			}
+		}
+	}
+
+	best := moves[0]
+	for _, move := range moves[1:] {
+		if move.Spec.Power > best.Spec.Power {
+			best = move
+		}
+	}
+	return best
+}
+
+// UseMove makes the KubeMon use the given Move on the target.
+// Whether a Move hits, lands a critical hit, how much damage it deals and whether it inflicts its ailment is decided by rng, in this order.
+// The damage is scaled by the effectiveness of the Move against the type of the target, as listed in chart.
+func (k *KubeMon) UseMove(move Move, target *KubeMon, rng *rand.Rand, chart TypeChart) (*AttackResult, error) {
+	result := &AttackResult{
+		Attacker: k.Name(),
+		Defender: target.Name(),
+		Move:     move.Name,
+	}
+
+	k.consumePP(move.Name)
+
+	if move.Spec.Power > 0 || move.Spec.Ailment != "" {
+		if !RollHit(rng, move.Spec.Accuracy) {
+			result.Missed = true
+			result.DefenderHP = *target.apiKubeMon.Status.HP
+			if err := k.updateStatus(); err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+	}
+
+	if move.Spec.Power > 0 {
+		attack := *k.apiKubeMon.Status.Attack
+		if k.Ailment() == kubemonv1.AilmentBurn {
+			attack /= 2
+		}
+		damage := CalculateDamage(k.Level(), move.Spec.Power, attack, *target.apiKubeMon.Status.Defense)
+		damage, result.Effectiveness = applyTypes(damage, move, k, target, chart)
+		if result.Effectiveness != EffectivenessNoEffect {
+			result.Damage, result.Critical = RollDamage(rng, damage)
+		}
+	}
+
+	// Ailments are only inflicted on KubeMons surviving the Move
+	if move.Spec.Ailment != "" && result.Effectiveness != EffectivenessNoEffect && result.Damage < target.HP() &&
+		RollAilment(rng, move.Spec.AilmentChance) {
+		if target.inflictAilment(move.Spec.Ailment, rng) {
+			result.Ailment = move.Spec.Ailment
+		}
+	}
+	if result.Damage > 0 || result.Ailment != "" {
+		if err := target.GetDamage(result.Damage); err != nil {
+			return nil, err
+		}
+	}
+	result.DefenderHP = *target.apiKubeMon.Status.HP
+
+	hp := *k.apiKubeMon.Status.HP
+	switch move.Spec.Effect {
+	case kubemonv1.MoveEffectHeal:
+		hp += k.MaxHP() / 2
+	case kubemonv1.MoveEffectDrain:
+		hp += result.Damage / 2
+	case kubemonv1.MoveEffectRecoil:
+		// Only Moves which dealt damage hurt their user
+		if result.Damage > 0 {
+			hp -= max(result.Damage/4, 1)
+		}
+	}
+	hp = max(min(hp, k.MaxHP()), 0)
+	result.Healed = max(hp-*k.apiKubeMon.Status.HP, 0)
+
+	if err := k.SetHealth(hp); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RestorePP refills the PP of all Moves of the KubeMon
+func (k *KubeMon) RestorePP() error {
+	for i := range k.apiKubeMon.Status.Moves {
+		status := &k.apiKubeMon.Status.Moves[i]
+		for _, move := range k.moves {
+			if move.Name == status.Name {
+				status.PP = move.Spec.PP
+			}
+		}
+	}
+	return k.updateStatus()
+}
+
+func (k *KubeMon) pp(name string) int32 {
+	for _, move := range k.apiKubeMon.Status.Moves {
+		if move.Name == name {
+			return move.PP
+		}
+	}
+	return 0
+}
+
+func (k *KubeMon) consumePP(name string) {
+	for i := range k.apiKubeMon.Status.Moves {
+		if k.apiKubeMon.Status.Moves[i].Name == name && k.apiKubeMon.Status.Moves[i].PP > 0 {
+			k.apiKubeMon.Status.Moves[i].PP--
+		}
+	}
+}
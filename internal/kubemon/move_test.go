@@ -0,0 +1,132 @@
+package kubemon
+
+import (
+	"math/rand"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+var (
+	testTackle  = Move{Name: "tackle", Spec: kubemonv1.MoveSpec{Power: 40, Accuracy: 100, PP: 35}}
+	testSlam    = Move{Name: "slam", Spec: kubemonv1.MoveSpec{Power: 80, Accuracy: 100, PP: 20}}
+	testRecover = Move{Name: "recover", Spec: kubemonv1.MoveSpec{Effect: kubemonv1.MoveEffectHeal, PP: 10}}
+)
+
+// withMoves teaches the KubeMon the given Moves with their full PP
+func withMoves(mon *KubeMon, moves ...Move) *KubeMon {
+	mon.moves = moves
+	mon.apiKubeMon.Status.Moves = nil
+	for _, move := range moves {
+		mon.apiKubeMon.Status.Moves = append(mon.apiKubeMon.Status.Moves, kubemonv1.KubeMonMove{Name: move.Name, PP: move.Spec.PP})
+	}
+	return mon
+}
+
+func TestSelectMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []Move
+		noPP  []string
+		hp    func(maxHP int32) int32
+		want  string
+	}{
+		{name: "strongest move", moves: []Move{testTackle, testSlam, testRecover}, want: "slam"},
+		{name: "heal at a third of the HP", moves: []Move{testTackle, testSlam, testRecover}, hp: func(maxHP int32) int32 { return maxHP / 3 }, want: "recover"},
+		{name: "attack above a third of the HP", moves: []Move{testTackle, testSlam, testRecover}, hp: func(maxHP int32) int32 { return maxHP/3 + 1 }, want: "slam"},
+		{name: "no heal move at low HP", moves: []Move{testTackle, testSlam}, hp: func(int32) int32 { return 1 }, want: "slam"},
+		{name: "moves without PP are skipped", moves: []Move{testTackle, testSlam}, noPP: []string{"slam"}, want: "tackle"},
+		{name: "struggle without PP", moves: []Move{testTackle}, noPP: []string{"tackle"}, want: StruggleMoveName},
+		{name: "struggle without moves", want: StruggleMoveName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mon := withMoves(newTestKubeMon(t, 50), tt.moves...)
+			for i := range mon.apiKubeMon.Status.Moves {
+				for _, name := range tt.noPP {
+					if mon.apiKubeMon.Status.Moves[i].Name == name {
+						mon.apiKubeMon.Status.Moves[i].PP = 0
+					}
+				}
+			}
+			if tt.hp != nil {
+				if err := mon.SetHealth(tt.hp(mon.MaxHP())); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := mon.SelectMove(newTestKubeMon(t, 50)); got.Name != tt.want {
+				t.Errorf("SelectMove() = %q, want %q", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestStruggle(t *testing.T) {
+	mon := newTestKubeMon(t, 5)
+	move := mon.struggle()
+	if move.Name != StruggleMoveName || move.Spec.Power != mon.apiKubeMon.Spec.Strength || move.Spec.Effect != kubemonv1.MoveEffectRecoil {
+		t.Errorf("struggle() = %+v, want a recoil Move with the strength of the KubeMon as power", move)
+	}
+}
+
+func TestUseMove(t *testing.T) {
+	chart := TypeChart{"normal": {NoEffect: []string{"serverless"}}}
+	recoil := Move{Name: "double-edge", Spec: kubemonv1.MoveSpec{Power: 80, Accuracy: 100, PP: 15, Effect: kubemonv1.MoveEffectRecoil}}
+
+	tests := []struct {
+		name       string
+		move       Move
+		targetType string
+		// hurt is the HP the attacker lacks before using the Move
+		hurt       int32
+		wantMissed bool
+		wantDamage bool
+		// wantHP returns the HP of the attacker after using the Move
+		wantHP func(hp, maxHP, damage int32) int32
+	}{
+		{name: "attack", move: testTackle, wantDamage: true, wantHP: func(hp, _, _ int32) int32 { return hp }},
+		{name: "missed attack", move: Move{Name: "miss", Spec: kubemonv1.MoveSpec{Power: 40, Accuracy: 0, PP: 5}}, wantMissed: true, wantHP: func(hp, _, _ int32) int32 { return hp }},
+		{name: "recoil", move: recoil, wantDamage: true, wantHP: func(hp, _, damage int32) int32 { return hp - max(damage/4, 1) }},
+		{name: "missed recoil", move: Move{Name: "miss", Spec: kubemonv1.MoveSpec{Power: 80, Accuracy: 0, PP: 5, Effect: kubemonv1.MoveEffectRecoil}}, wantMissed: true, wantHP: func(hp, _, _ int32) int32 { return hp }},
+		{name: "recoil without effect", move: Move{Name: "normal-edge", Spec: kubemonv1.MoveSpec{Power: 80, Accuracy: 100, PP: 5, Type: "normal", Effect: kubemonv1.MoveEffectRecoil}}, targetType: "serverless", wantHP: func(hp, _, _ int32) int32 { return hp }},
+		{name: "heal", move: testRecover, hurt: 30, wantHP: func(hp, maxHP, _ int32) int32 { return min(hp+maxHP/2, maxHP) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attacker := withMoves(newTestKubeMon(t, 50), tt.move)
+			if err := attacker.SetHealth(attacker.MaxHP() - tt.hurt); err != nil {
+				t.Fatal(err)
+			}
+			target := newTestKubeMon(t, 50)
+			if tt.targetType != "" {
+				target.species = &kubemonv1.Species{ObjectMeta: metav1.ObjectMeta{Name: "target"}, Spec: target.species.Spec}
+				target.species.Spec.Type = tt.targetType
+			}
+			hp, targetHP := attacker.HP(), target.HP()
+
+			result, err := attacker.UseMove(tt.move, target, rand.New(rand.NewSource(1)), chart)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Missed != tt.wantMissed {
+				t.Errorf("Missed = %t, want %t", result.Missed, tt.wantMissed)
+			}
+			if (result.Damage > 0) != tt.wantDamage {
+				t.Errorf("Damage = %d, want damage %t", result.Damage, tt.wantDamage)
+			}
+			if target.HP() != targetHP-result.Damage || result.DefenderHP != target.HP() {
+				t.Errorf("target HP = %d, DefenderHP = %d, want %d", target.HP(), result.DefenderHP, targetHP-result.Damage)
+			}
+			if want := tt.wantHP(hp, attacker.MaxHP(), result.Damage); attacker.HP() != want {
+				t.Errorf("attacker HP = %d, want %d", attacker.HP(), want)
+			}
+			if got := attacker.pp(tt.move.Name); got != tt.move.Spec.PP-1 {
+				t.Errorf("PP = %d, want %d", got, tt.move.Spec.PP-1)
+			}
+		})
+	}
+}
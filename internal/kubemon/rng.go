@@ -0,0 +1,48 @@
+package kubemon
+
+import (
+	"math/rand"
+
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// criticalHitRate is the inverse chance of a Move landing a critical hit
+	criticalHitRate = 16
+	// minDamageSpread is the lowest percentage of the damage a Move deals, the highest is 100
+	minDamageSpread = 85
+)
+
+// BattleRand returns the random number generator for a turn of a Fight.
+// It only depends on the seed of the Fight and the turn, so every turn of a Fight can be replayed from its seed.
+func BattleRand(seed int64, turn int32) *rand.Rand {
+	return rand.New(rand.NewSource(int64(splitMix64(uint64(seed) + uint64(turn)*0x9e3779b97f4a7c15))))
+}
+
+// splitMix64 scrambles x, so the generators of consecutive turns are not correlated
+func splitMix64(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// RollHit reports whether a Move with the given accuracy in percent hits its target
+func RollHit(rng *rand.Rand, accuracy int32) bool {
+	return rng.Int31n(100) < accuracy
+}
+
+// RollAilment reports whether a Move inflicts its ailment, given its chance in percent
+func RollAilment(rng *rand.Rand, chance *int32) bool {
+	return rng.Int31n(100) < ptr.Deref(chance, 100)
+}
+
+// RollDamage varies the damage of a Move. It may land a critical hit, which deals 50% more damage,
+// and the damage is spread randomly between 85% and 100%.
+func RollDamage(rng *rand.Rand, damage int32) (int32, bool) {
+	critical := rng.Intn(criticalHitRate) == 0
+	if critical {
+		damage = damage * 3 / 2
+	}
+	damage = damage * (minDamageSpread + rng.Int31n(100-minDamageSpread+1)) / 100
+	return max(damage, 1), critical
+}
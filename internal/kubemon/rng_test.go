@@ -0,0 +1,28 @@
+package kubemon
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBattleRand(t *testing.T) {
+	draw := func(seed int64, turn int32) []int64 {
+		rng := BattleRand(seed, turn)
+		numbers := make([]int64, 8)
+		for i := range numbers {
+			numbers[i] = rng.Int63()
+		}
+		return numbers
+	}
+
+	first := draw(42, 3)
+	if again := draw(42, 3); !slices.Equal(first, again) {
+		t.Errorf("BattleRand(42, 3) = %v, then %v, want the same numbers", first, again)
+	}
+	if next := draw(42, 4); slices.Equal(first, next) {
+		t.Errorf("BattleRand(42, 4) = %v, want other numbers than turn 3", next)
+	}
+	if other := draw(43, 3); slices.Equal(first, other) {
+		t.Errorf("BattleRand(43, 3) = %v, want other numbers than seed 42", other)
+	}
+}
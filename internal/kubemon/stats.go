@@ -0,0 +1,31 @@
+package kubemon
+
+import "math"
+
+// maxDamage caps the damage calculated for a single Move, so scaling it by bonuses and random spread cannot overflow
+const maxDamage = 1_000_000
+
+// CalculateMaxHP returns the maximum HP of a KubeMon with the given base HP and level.
+// It is calculated in int64 and capped at math.MaxInt32, so Species created before base stats were limited cannot overflow it.
+func CalculateMaxHP(baseHP, level int32) int32 {
+	return int32(min((2*int64(baseHP)*int64(level))/100+int64(level)+10, math.MaxInt32))
+}
+
+// CalculateStat returns the value of a stat of a KubeMon with the given base stat and level, capped like CalculateMaxHP
+func CalculateStat(base, level int32) int32 {
+	return int32(min((2*int64(base)*int64(level))/100+5, math.MaxInt32))
+}
+
+// CalculateDamage returns the damage an attack with the given power deals, at most maxDamage.
+// It is calculated in int64, as boosted stats can grow without bound.
+func CalculateDamage(level, power, attack, defense int32) int32 {
+	if power <= 0 {
+		return 0
+	}
+	if defense < 1 {
+		defense = 1
+	}
+
+	damage := (int64(2*level/5+2)*int64(power)*int64(max(attack, 0))/int64(defense))/50 + 2
+	return int32(min(damage, maxDamage))
+}
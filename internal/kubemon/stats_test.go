@@ -0,0 +1,49 @@
+package kubemon
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateStats(t *testing.T) {
+	tests := []struct {
+		name                string
+		base, level         int32
+		wantMaxHP, wantStat int32
+	}{
+		{name: "level 1", base: 45, level: 1, wantMaxHP: 11, wantStat: 5},
+		{name: "level 50", base: 45, level: 50, wantMaxHP: 105, wantStat: 50},
+		{name: "highest base stat at the level cap", base: 255, level: 100, wantMaxHP: 620, wantStat: 515},
+		{name: "base stat beyond the limit does not overflow", base: math.MaxInt32, level: 100, wantMaxHP: math.MaxInt32, wantStat: math.MaxInt32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateMaxHP(tt.base, tt.level); got != tt.wantMaxHP {
+				t.Errorf("CalculateMaxHP(%d, %d) = %d, want %d", tt.base, tt.level, got, tt.wantMaxHP)
+			}
+			if got := CalculateStat(tt.base, tt.level); got != tt.wantStat {
+				t.Errorf("CalculateStat(%d, %d) = %d, want %d", tt.base, tt.level, got, tt.wantStat)
+			}
+		})
+	}
+}
+
+func TestCalculateDamage(t *testing.T) {
+	tests := []struct {
+		name                          string
+		level, power, attack, defense int32
+		want                          int32
+	}{
+		{name: "status move", level: 10, power: 0, attack: 20, defense: 20, want: 0},
+		{name: "regular move", level: 10, power: 40, attack: 20, defense: 20, want: 6},
+		{name: "zero defense", level: 10, power: 40, attack: 20, defense: 0, want: 98},
+		{name: "boosted attack does not overflow", level: 100, power: 255, attack: math.MaxInt32, defense: 1, want: maxDamage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateDamage(tt.level, tt.power, tt.attack, tt.defense); got != tt.want {
+				t.Errorf("CalculateDamage(%d, %d, %d, %d) = %d, want %d", tt.level, tt.power, tt.attack, tt.defense, got, tt.want)
+			}
+		})
+	}
+}
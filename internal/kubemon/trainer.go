@@ -0,0 +1,106 @@
+package kubemon
+
+import (
+	"fmt"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	ReasonTrainerFound    = "TrainerFound"
+	ReasonTrainerNotFound = "TrainerNotFound"
+	ReasonWild            = "Wild"
+)
+
+var (
+	trainerKind = kubemonv1.GroupVersion.WithKind("Trainer")
+)
+
+// ResolveTrainer verifies that the Trainer owning the KubeMon exists and makes it an owner of the KubeMon.
+// Owner references to Trainers which no longer own the KubeMon are removed.
+func (k *KubeMon) ResolveTrainer() error {
+	owner := k.Owner()
+
+	var trainer *kubemonv1.Trainer
+	if owner != "" {
+		trainer = &kubemonv1.Trainer{}
+		err := k.client.Get(k.ctx, types.NamespacedName{Namespace: k.apiKubeMon.Namespace, Name: owner}, trainer)
+		if apierrors.IsNotFound(err) {
+			trainer = nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	refs := make([]metav1.OwnerReference, 0, len(k.apiKubeMon.OwnerReferences)+1)
+	found := false
+	for _, ref := range k.apiKubeMon.OwnerReferences {
+		if ref.APIVersion != trainerKind.GroupVersion().String() || ref.Kind != trainerKind.Kind {
+			refs = append(refs, ref)
+			continue
+		}
+		if trainer != nil && ref.UID == trainer.UID {
+			refs = append(refs, ref)
+			found = true
+		}
+	}
+	if trainer != nil && !found {
+		refs = append(refs, metav1.OwnerReference{
+			APIVersion:         trainerKind.GroupVersion().String(),
+			Kind:               trainerKind.Kind,
+			Name:               trainer.Name,
+			UID:                trainer.UID,
+			BlockOwnerDeletion: ptr.To(true),
+		})
+	}
+	if !equality.Semantic.DeepEqual(refs, k.apiKubeMon.OwnerReferences) {
+		k.apiKubeMon.OwnerReferences = refs
+		if err := k.update(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case owner == "":
+		return k.setCondition(kubemonv1.KubeMonConditionTrainerResolved, metav1.ConditionFalse, ReasonWild,
+			"KubeMon is wild and has no Trainer")
+	case trainer == nil:
+		return k.setCondition(kubemonv1.KubeMonConditionTrainerResolved, metav1.ConditionFalse, ReasonTrainerNotFound,
+			fmt.Sprintf("Trainer %q does not exist", owner))
+	default:
+		return k.setCondition(kubemonv1.KubeMonConditionTrainerResolved, metav1.ConditionTrue, ReasonTrainerFound,
+			fmt.Sprintf("Trainer %q found", owner))
+	}
+}
+
+// RecordFightResult counts a won or lost Fight of the KubeMon and adjusts its friendship
+func (k *KubeMon) RecordFightResult(won bool) error {
+	k.recordFightResult(won)
+	return k.updateStatus()
+}
+
+// RecordWin counts a won Fight of the KubeMon and adds the experience gained by it in a single update.
+// It returns the number of levels gained.
+func (k *KubeMon) RecordWin(xp int32) (int32, error) {
+	levels := k.gainExperience(xp)
+	k.recordFightResult(true)
+	if err := k.updateStatus(); err != nil {
+		return 0, err
+	}
+	return levels, nil
+}
+
+func (k *KubeMon) recordFightResult(won bool) {
+	if won {
+		k.apiKubeMon.Status.Wins++
+		k.addFriendship(FriendshipWin)
+	} else {
+		k.apiKubeMon.Status.Losses++
+		k.addFriendship(FriendshipLoss)
+	}
+}
@@ -0,0 +1,108 @@
+package kubemon
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+)
+
+// stabBonus is the bonus in percent for a KubeMon using a Move of the same type as its Species
+const stabBonus = 150
+
+//go:embed typechart.yaml
+var defaultTypeChart []byte
+
+// Effectiveness describes how well a Move works against the type of its target
+type Effectiveness string
+
+const (
+	EffectivenessNeutral          Effectiveness = ""
+	EffectivenessSuperEffective   Effectiveness = "SuperEffective"
+	EffectivenessNotVeryEffective Effectiveness = "NotVeryEffective"
+	EffectivenessNoEffect         Effectiveness = "NoEffect"
+)
+
+// percent returns the share of the damage dealt with the given effectiveness
+func (e Effectiveness) percent() int32 {
+	switch e {
+	case EffectivenessSuperEffective:
+		return 200
+	case EffectivenessNotVeryEffective:
+		return 50
+	case EffectivenessNoEffect:
+		return 0
+	}
+	return 100
+}
+
+// TypeMatchups lists the types a Move of one type is super effective, not very effective or not effective at all against
+type TypeMatchups struct {
+	SuperEffective   []string `json:"superEffective,omitempty"`
+	NotVeryEffective []string `json:"notVeryEffective,omitempty"`
+	NoEffect         []string `json:"noEffect,omitempty"`
+}
+
+// TypeChart maps the type of a Move to its matchups. Types which are not part of the chart are neutral against everything.
+type TypeChart map[string]TypeMatchups
+
+// LoadTypeChart reads the type chart from the YAML file at path, or returns the default chart if path is empty
+func LoadTypeChart(path string) (TypeChart, error) {
+	data := defaultTypeChart
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return ParseTypeChart(data)
+}
+
+// ParseTypeChart parses a type chart from YAML and checks that no matchup is listed twice
+func ParseTypeChart(data []byte) (TypeChart, error) {
+	var chart TypeChart
+	if err := yaml.UnmarshalStrict(data, &chart); err != nil {
+		return nil, fmt.Errorf("invalid type chart: %w", err)
+	}
+
+	for moveType, matchups := range chart {
+		seen := map[string]bool{}
+		for _, types := range [][]string{matchups.SuperEffective, matchups.NotVeryEffective, matchups.NoEffect} {
+			for _, t := range types {
+				if seen[t] {
+					return nil, fmt.Errorf("invalid type chart: %s is listed more than once for %s", t, moveType)
+				}
+				seen[t] = true
+			}
+		}
+	}
+	return chart, nil
+}
+
+// Effectiveness returns how well a Move of moveType works against a KubeMon of targetType
+func (c TypeChart) Effectiveness(moveType, targetType string) Effectiveness {
+	matchups, ok := c[moveType]
+	if !ok || targetType == "" {
+		return EffectivenessNeutral
+	}
+	switch {
+	case slices.Contains(matchups.NoEffect, targetType):
+		return EffectivenessNoEffect
+	case slices.Contains(matchups.NotVeryEffective, targetType):
+		return EffectivenessNotVeryEffective
+	case slices.Contains(matchups.SuperEffective, targetType):
+		return EffectivenessSuperEffective
+	}
+	return EffectivenessNeutral
+}
+
+// applyTypes scales the damage of a Move by the same-type attack bonus and its effectiveness against the target
+func applyTypes(damage int32, move Move, attacker, target *KubeMon, chart TypeChart) (int32, Effectiveness) {
+	if move.Spec.Type != "" && move.Spec.Type == attacker.species.Spec.Type {
+		damage = damage * stabBonus / 100
+	}
+	effectiveness := chart.Effectiveness(move.Spec.Type, target.species.Spec.Type)
+	return damage * effectiveness.percent() / 100, effectiveness
+}
@@ -0,0 +1,101 @@
+package kubemon
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+func TestParseTypeChart(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "empty", data: ""},
+		{name: "valid", data: "normal:\n  notVeryEffective: [storage]\n  noEffect: [serverless]\n"},
+		{name: "type listed twice", data: "normal:\n  superEffective: [storage]\n  notVeryEffective: [storage]\n", wantErr: true},
+		{name: "unknown field", data: "normal:\n  veryEffective: [storage]\n", wantErr: true},
+		{name: "not a chart", data: "- normal\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseTypeChart([]byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("ParseTypeChart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadDefaultTypeChart(t *testing.T) {
+	chart, err := LoadTypeChart("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart) == 0 {
+		t.Error("default type chart is empty")
+	}
+}
+
+func TestEffectiveness(t *testing.T) {
+	chart := TypeChart{
+		"container": {SuperEffective: []string{"vm"}, NotVeryEffective: []string{"container"}},
+		"normal":    {NoEffect: []string{"serverless"}},
+	}
+	tests := []struct {
+		chart                TypeChart
+		moveType, targetType string
+		want                 Effectiveness
+	}{
+		{chart: chart, moveType: "container", targetType: "vm", want: EffectivenessSuperEffective},
+		{chart: chart, moveType: "container", targetType: "container", want: EffectivenessNotVeryEffective},
+		{chart: chart, moveType: "normal", targetType: "serverless", want: EffectivenessNoEffect},
+		{chart: chart, moveType: "container", targetType: "network", want: EffectivenessNeutral},
+		{chart: chart, moveType: "network", targetType: "vm", want: EffectivenessNeutral},
+		{chart: chart, moveType: "", targetType: "vm", want: EffectivenessNeutral},
+		{chart: chart, moveType: "container", targetType: "", want: EffectivenessNeutral},
+		{chart: nil, moveType: "container", targetType: "vm", want: EffectivenessNeutral},
+	}
+	for _, tt := range tests {
+		if got := tt.chart.Effectiveness(tt.moveType, tt.targetType); got != tt.want {
+			t.Errorf("Effectiveness(%q, %q) = %q, want %q", tt.moveType, tt.targetType, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTypes(t *testing.T) {
+	chart := TypeChart{
+		"container": {SuperEffective: []string{"vm"}, NotVeryEffective: []string{"storage"}},
+		"normal":    {NoEffect: []string{"serverless"}},
+	}
+	tests := []struct {
+		name              string
+		moveType          string
+		targetType        string
+		want              int32
+		wantEffectiveness Effectiveness
+	}{
+		{name: "neutral", moveType: "network", targetType: "network", want: 100},
+		{name: "same-type attack bonus", moveType: "container", targetType: "network", want: 150},
+		{name: "super effective with bonus", moveType: "container", targetType: "vm", want: 300, wantEffectiveness: EffectivenessSuperEffective},
+		{name: "not very effective with bonus", moveType: "container", targetType: "storage", want: 75, wantEffectiveness: EffectivenessNotVeryEffective},
+		{name: "no effect", moveType: "normal", targetType: "serverless", want: 0, wantEffectiveness: EffectivenessNoEffect},
+		{name: "untyped move", moveType: "", targetType: "vm", want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The attacker is of testSpecies, which is of the container type
+			attacker := newTestKubeMon(t, 10)
+			target := newTestKubeMon(t, 10)
+			target.species = &kubemonv1.Species{ObjectMeta: metav1.ObjectMeta{Name: "target"}, Spec: kubemonv1.SpeciesSpec{Type: tt.targetType}}
+			move := Move{Name: "move", Spec: kubemonv1.MoveSpec{Power: 40, Type: tt.moveType}}
+
+			got, effectiveness := applyTypes(100, move, attacker, target, chart)
+			if got != tt.want || effectiveness != tt.wantEffectiveness {
+				t.Errorf("applyTypes() = %d, %q, want %d, %q", got, effectiveness, tt.want, tt.wantEffectiveness)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+const namespace = "kubemon"
+
+var (
+	// FightsStarted counts the Fights which started, by mode
+	FightsStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fights_started_total",
+		Help:      "Number of Fights which started",
+	}, []string{"mode"})
+	// FightsEnded counts the Fights which ended, by mode and phase
+	FightsEnded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fights_ended_total",
+		Help:      "Number of Fights which finished or were aborted",
+	}, []string{"mode", "phase"})
+	// FightTurns observes the number of turns of ended Fights
+	FightTurns = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "fight_turns",
+		Help:      "Number of turns Fights lasted",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+	})
+	// Damage observes the damage dealt by Moves
+	Damage = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "damage",
+		Help:      "Damage dealt by Moves in Fights",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	})
+	// Heals counts the heal actions of KubeMons
+	Heals = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "heals_total",
+		Help:      "Number of KubeMons healed by the heal action",
+	})
+	// LevelUps counts the levels gained by KubeMons, by species
+	LevelUps = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "level_ups_total",
+		Help:      "Number of levels gained by KubeMons",
+	}, []string{"species"})
+	// Evolutions counts the KubeMons which evolved, by their previous and new species
+	Evolutions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "evolutions_total",
+		Help:      "Number of KubeMons which evolved",
+	}, []string{"from", "to"})
+
+	kubeMonsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "kubemons"),
+		"Number of live KubeMons by species and level",
+		[]string{"species", "level"}, nil,
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(FightsStarted, FightsEnded, FightTurns, Damage, Heals, LevelUps, Evolutions)
+}
+
+// kubeMonCollector reports the number of live KubeMons on every scrape, read from the cache of the manager
+type kubeMonCollector struct {
+	reader client.Reader
+}
+
+// RegisterKubeMonCollector registers the gauge of live KubeMons, which are listed using the given reader
+func RegisterKubeMonCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(&kubeMonCollector{reader: reader})
+}
+
+func (c *kubeMonCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kubeMonsDesc
+}
+
+func (c *kubeMonCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var mons kubemonv1.KubeMonList
+	if err := c.reader.List(ctx, &mons); err != nil {
+		ch <- prometheus.NewInvalidMetric(kubeMonsDesc, err)
+		return
+	}
+
+	type key struct {
+		species string
+		level   int32
+	}
+	counts := map[key]int{}
+	for _, mon := range mons.Items {
+		if mon.DeletionTimestamp != nil || mon.Status.Level == nil {
+			continue
+		}
+		counts[key{species: mon.Spec.Species, level: *mon.Status.Level}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(kubeMonsDesc, prometheus.GaugeValue, float64(count), k.species, strconv.Itoa(int(k.level)))
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubemonv1 "github.com/memeToasty/kubemon/api/v1"
+)
+
+func TestKubeMonCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kubemonv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newMon := func(name, species string, level *int32) *kubemonv1.KubeMon {
+		return &kubemonv1.KubeMon{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       kubemonv1.KubeMonSpec{Species: species},
+			Status:     kubemonv1.KubeMonStatus{Level: level},
+		}
+	}
+	deleted := newMon("deleted", "podling", ptr.To(int32(5)))
+	deleted.DeletionTimestamp = ptr.To(metav1.Now())
+	deleted.Finalizers = []string{"kubemon.memetoasty.github.com/test"}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newMon("podling-1", "podling", ptr.To(int32(5))),
+			newMon("podling-2", "podling", ptr.To(int32(5))),
+			newMon("podling-3", "podling", ptr.To(int32(12))),
+			newMon("nodeling", "nodeling", ptr.To(int32(5))),
+			// KubeMons which are not initialized yet or are being deleted are not counted
+			newMon("uninitialized", "podling", nil),
+			deleted,
+		).
+		Build()
+
+	expected := `
+# HELP kubemon_kubemons Number of live KubeMons by species and level
+# TYPE kubemon_kubemons gauge
+kubemon_kubemons{level="12",species="podling"} 1
+kubemon_kubemons{level="5",species="nodeling"} 1
+kubemon_kubemons{level="5",species="podling"} 2
+`
+	if err := testutil.CollectAndCompare(&kubeMonCollector{reader: c}, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}